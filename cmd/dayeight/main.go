@@ -1,6 +1,7 @@
 package main
 
 import (
+	"adventofcode2025/internal/input"
 	"adventofcode2025/internal/mathutils"
 	"adventofcode2025/internal/refutils"
 	"adventofcode2025/internal/spatial"
@@ -13,6 +14,7 @@ import (
 )
 
 type JunctionBox struct {
+	_ struct{} `aoc:"{X},{Y},{Z}"`
 	X float64
 	Y float64
 	Z float64
@@ -282,7 +284,7 @@ func readPointsFromFile(path string) ([]JunctionBox, error) {
 	coords := make([]JunctionBox, 0)
 	for scanner.Scan() {
 		var c JunctionBox
-		_, err := fmt.Sscanf(scanner.Text(), "%f,%f,%f", &c.X, &c.Y, &c.Z)
+		err := input.Unmarshal(scanner.Text(), &c)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing line %q: %w", scanner.Text(), err)
 		}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"adventofcode2025/internal/graph"
+)
+
+type dotOptions struct {
+	sources   []string
+	sinks     []string
+	waypoints []string
+	countsTo  string
+	between   string
+}
+
+func writeDOT(path string, g *graph.Digraph[string], opts dotOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	if err := renderDOT(w, g, opts); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func renderDOT(w io.Writer, g *graph.Digraph[string], opts dotOptions) error {
+	out := g.Clone()
+	if opts.between != "" {
+		from, to, ok := strings.Cut(opts.between, ":")
+		if !ok || from == "" || to == "" {
+			return fmt.Errorf("invalid subgraph %q, want from:to", opts.between)
+		}
+		sub, err := g.Between(from, to)
+		if err != nil {
+			return fmt.Errorf("error restricting graph: %w", err)
+		}
+		out = sub
+	}
+
+	highlights := []struct {
+		names  []string
+		colour string
+	}{
+		{opts.sources, "#a9dfbf"},
+		{opts.sinks, "#f5b7b1"},
+		{opts.waypoints, "#aed6f1"},
+	}
+	for _, h := range highlights {
+		for _, name := range h.names {
+			if !out.HasNode(name) {
+				continue
+			}
+			out.SetNodeAttr(name, "style", "filled")
+			out.SetNodeAttr(name, "fillcolor", h.colour)
+		}
+	}
+
+	if opts.countsTo != "" {
+		counts, err := graph.CountPathsToFiniteWith(g, graph.BigInt, opts.countsTo)
+		if err != nil {
+			return fmt.Errorf("error counting paths: %w", err)
+		}
+		ancestors, err := g.Ancestors(opts.countsTo)
+		if err != nil {
+			return fmt.Errorf("error counting paths: %w", err)
+		}
+		for _, name := range ancestors {
+			if _, ok := counts[name]; !ok && out.HasNode(name) {
+				out.SetNodeAttr(name, "label", name+"\n(cycle)")
+			}
+		}
+		for _, name := range out.Nodes() {
+			if count, ok := counts[name]; ok {
+				out.SetNodeAttr(name, "label", fmt.Sprintf("%s\n%v", name, count))
+			}
+		}
+	}
+
+	return out.WriteDOT(w, "devices")
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"adventofcode2025/internal/graph"
+)
+
+type explainOptions struct {
+	list     int
+	shortest int
+	sample   int
+	seed     int64
+}
+
+func explainPaths(w io.Writer, g *graph.Digraph[string], from, to string, opts explainOptions) error {
+	if opts.list > 0 {
+		if _, err := fmt.Fprintf(w, "First %d paths from %s to %s:\n", opts.list, from, to); err != nil {
+			return err
+		}
+		n := 0
+		for path := range g.Paths(from, to) {
+			if err := writePath(w, path); err != nil {
+				return err
+			}
+			n++
+			if n == opts.list {
+				break
+			}
+		}
+	}
+
+	if opts.shortest > 0 {
+		paths, err := g.ShortestPaths(from, to, opts.shortest)
+		if err != nil {
+			return fmt.Errorf("error finding shortest paths: %w", err)
+		}
+		if _, err := fmt.Fprintf(w, "%d shortest paths from %s to %s:\n", opts.shortest, from, to); err != nil {
+			return err
+		}
+		for _, path := range paths {
+			if err := writePath(w, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.sample > 0 {
+		paths, err := g.SamplePaths(from, to, opts.sample, rand.New(rand.NewSource(opts.seed)))
+		if err != nil {
+			return fmt.Errorf("error sampling paths: %w", err)
+		}
+		if _, err := fmt.Fprintf(w, "%d random paths from %s to %s:\n", opts.sample, from, to); err != nil {
+			return err
+		}
+		for _, path := range paths {
+			if err := writePath(w, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writePath(w io.Writer, path []string) error {
+	_, err := fmt.Fprintf(w, "  %s (%d hops)\n", strings.Join(path, " -> "), len(path)-1)
+	return err
+}
@@ -1,9 +1,15 @@
 package main
 
 import (
+	"adventofcode2025/internal/graph"
+	"adventofcode2025/internal/lex"
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -15,29 +21,72 @@ func main() {
 }
 
 func run() error {
-	if len(os.Args) < 2 {
-		return fmt.Errorf("usage: go run . <path/to/input/file>")
+	from := flag.String("from", "svr", "node the waypoint paths start from")
+	to := flag.String("to", "out", "node the waypoint paths end at")
+	via := flag.String("via", "dac,fft", "comma-separated waypoints every path must visit")
+	ordered := flag.Bool("ordered", false, "visit the waypoints in the order given")
+	simpleLimit := flag.Int("simple-limit", 0, "on cyclic input count simple paths instead, failing after exploring more than this many (0 disables)")
+	useBig := flag.Bool("big", false, "count paths with arbitrary-precision integers")
+	modulus := flag.Uint64("mod", 0, "report path counts modulo this value (0 disables)")
+	dotPath := flag.String("dot", "", "write the device graph to a Graphviz DOT file")
+	dotCounts := flag.Bool("dot-counts", false, "label DOT nodes with their path counts to --to")
+	dotBetween := flag.String("dot-between", "", "restrict the DOT output to nodes on paths from:to")
+	list := flag.Int("list", 0, "print the first n paths from --from to --to")
+	shortest := flag.Int("shortest", 0, "print the k shortest paths from --from to --to by hop count")
+	sample := flag.Int("sample", 0, "print n uniformly random paths from --from to --to")
+	seed := flag.Int64("seed", 1, "random seed for --sample")
+	merge := flag.Bool("merge", false, "merge the children of repeated parent lines instead of failing")
+	flag.Parse()
+
+	args := flag.Args()
+	command := "count"
+	if len(args) > 0 {
+		if _, ok := queries[args[0]]; ok || args[0] == "count" {
+			command = args[0]
+			args = args[1:]
+		}
+	}
+
+	if len(args) < 1 {
+		return fmt.Errorf("usage: go run . [--from svr] [--to out] [--via dac,fft] [--ordered] [--simple-limit n] [--big] [--mod m] [--dot out.dot] [--dot-counts] [--dot-between from:to] [--list n] [--shortest k] [--sample n] [--seed s] [--merge] [count|%s] <path/to/input/file> [node]", strings.Join(queryNames(), "|"))
 	}
 
-	file, err := os.Open(os.Args[1])
+	parser, err := readParser(args[0], *merge)
 	if err != nil {
-		return fmt.Errorf("error opening file: %w", err)
+		return err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error closing file: %v\n", err)
-		}
-	}()
 
-	scanner := bufio.NewScanner(file)
-	parser := NewParser()
+	sinks := []string{*to}
+	if exit, err := parser.GetExit(); err == nil {
+		sinks = append(sinks, exit.Name)
+	}
+	if undefined := parser.Undefined(sinks...); len(undefined) > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "Referenced but never defined: %s\n", strings.Join(undefined, ", "))
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		_, err := parser.Deserialize(line)
-		if err != nil {
-			return fmt.Errorf("error deserializing: %v", err)
+	if *dotPath != "" {
+		opts := dotOptions{
+			sources:   []string{*from},
+			sinks:     []string{*to},
+			waypoints: parseWaypoints(*via),
+			between:   *dotBetween,
+		}
+		if root, err := parser.GetRoot(); err == nil {
+			opts.sources = append(opts.sources, root.Name)
+		}
+		if exit, err := parser.GetExit(); err == nil {
+			opts.sinks = append(opts.sinks, exit.Name)
+		}
+		if *dotCounts {
+			opts.countsTo = *to
 		}
+		if err := writeDOT(*dotPath, parser.Graph(), opts); err != nil {
+			return fmt.Errorf("error writing dot: %w", err)
+		}
+	}
+
+	if command != "count" {
+		return runQuery(os.Stdout, command, parser.Graph(), *from, *to, args[1:])
 	}
 
 	root, err := parser.GetRoot()
@@ -50,100 +99,140 @@ func run() error {
 		return fmt.Errorf("error getting exit: %w", err)
 	}
 
-	paths := root.CountPaths(exit)
-	println("Found: ", paths)
+	opts := countOptions{
+		simpleLimit: *simpleLimit,
+		big:         *useBig,
+		modulus:     *modulus,
+	}
 
-	server, err := parser.GetNode("svr")
+	paths, err := countPaths(parser.Graph(), root.Name, exit.Name, nil, opts)
 	if err != nil {
-		return fmt.Errorf("error getting server: %w", err)
+		return fmt.Errorf("error counting paths: %w", err)
 	}
+	println("Found: ", paths)
 
-	stops, err := NewStops(parser)
+	opts.ordered = *ordered
+	paths, err = countPaths(parser.Graph(), *from, *to, parseWaypoints(*via), opts)
 	if err != nil {
-		return fmt.Errorf("error getting stops: %w", err)
+		return fmt.Errorf("error counting paths: %w", err)
 	}
 
-	paths = countPathsWithStops(server, exit, stops)
-
 	println("Found: ", paths)
 
+	explain := explainOptions{
+		list:     *list,
+		shortest: *shortest,
+		sample:   *sample,
+		seed:     *seed,
+	}
+	if err := explainPaths(os.Stdout, parser.Graph(), *from, *to, explain); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func countPathsWithStops(root, other *Node, stops *Stops) int {
-	var paths int
-	{
-		branch := root.CountPaths(stops.dac)
-		branch *= stops.dac.CountPaths(stops.fft)
-		branch *= stops.fft.CountPaths(other)
-		paths = branch
+type countOptions struct {
+	ordered     bool
+	simpleLimit int
+	big         bool
+	modulus     uint64
+}
+
+func readParser(path string, merge bool) (*Parser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
 	}
-	{
-		branch := root.CountPaths(stops.fft)
-		branch *= stops.fft.CountPaths(stops.dac)
-		branch *= stops.dac.CountPaths(other)
-		paths += branch
+	defer func() {
+		if err := file.Close(); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error closing file: %v\n", err)
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	parser := NewParser()
+	parser.MergeDuplicates = merge
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		_, err := parser.Deserialize(line)
+		if err != nil {
+			return nil, fmt.Errorf("error deserializing: %w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
 	}
 
-	return paths
+	return parser, nil
 }
 
-type Stops struct {
-	dac *Node
-	fft *Node
+func countPaths(g *graph.Digraph[string], from, to string, via []string, opts countOptions) (string, error) {
+	switch {
+	case opts.modulus > 0:
+		return countPathsWith(g, graph.Mod(opts.modulus), from, to, via, opts)
+	case opts.big:
+		return countPathsWith(g, graph.BigInt, from, to, via, opts)
+	default:
+		return countPathsWith(g, graph.Int, from, to, via, opts)
+	}
 }
 
-func NewStops(p *Parser) (*Stops, error) {
+func countPathsWith[V any](g *graph.Digraph[string], c graph.Counter[V], from, to string, via []string, opts countOptions) (string, error) {
+	count, err := graph.CountPathsViaWith(g, c, from, to, via, opts.ordered)
 
-	stops := &Stops{}
-	for _, name := range []string{"dac", "fft"} {
-		node, err := p.GetNode(name)
+	var cycle *graph.CycleError[string]
+	if opts.simpleLimit > 0 && errors.As(err, &cycle) {
+		simple, err := g.CountSimplePathsVia(from, to, via, opts.ordered, opts.simpleLimit)
 		if err != nil {
-			return nil, fmt.Errorf("error getting node: %w", err)
+			return "", err
 		}
-		switch name {
-		case "dac":
-			stops.dac = node
-		case "fft":
-			stops.fft = node
-		default:
-			return nil, fmt.Errorf("unknown node: %s", name)
+		if opts.modulus > 0 {
+			return strconv.FormatUint(uint64(simple)%opts.modulus, 10), nil
 		}
+		return strconv.Itoa(simple), nil
 	}
-
-	if stops.dac == nil {
-		return nil, fmt.Errorf("could not find dac")
-	}
-	if stops.fft == nil {
-		return nil, fmt.Errorf("could not find fft")
+	if err != nil {
+		return "", err
 	}
 
-	return stops, nil
+	return fmt.Sprint(count), nil
+}
+
+func parseWaypoints(s string) []string {
+	var waypoints []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			waypoints = append(waypoints, name)
+		}
+	}
+	return waypoints
 }
 
 type Node struct {
-	Name     string
-	children map[string]*Node
+	Name  string
+	graph *graph.Digraph[string]
 }
 
-func NewNode(name string) *Node {
+func NewNode(g *graph.Digraph[string], name string) *Node {
+	g.AddNode(name)
 	return &Node{
-		Name:     name,
-		children: make(map[string]*Node),
+		Name:  name,
+		graph: g,
 	}
 }
 
 func (n *Node) AddChild(child *Node) error {
-	if _, ok := n.children[child.Name]; ok {
+	if n.graph.HasEdge(n.Name, child.Name) {
 		return fmt.Errorf("child %s already exists", child.Name)
 	}
-	n.children[child.Name] = child
-	return nil
+	return n.graph.AddEdge(n.Name, child.Name)
 }
 
 func (n *Node) ForEachChild(fn func(n *Node)) {
-	for _, child := range n.children {
-		fn(child)
+	for _, name := range n.graph.Successors(n.Name) {
+		fn(&Node{Name: name, graph: n.graph})
 	}
 }
 
@@ -170,46 +259,47 @@ func (n *Node) Reachable(other *Node, maxDepth int) bool {
 	return found
 }
 
-func (n *Node) CountPaths(other *Node) int {
-	memo := make(map[*Node]int)
-
-	var loop func(*Node) int
-	loop = func(node *Node) int {
-		if node.Name == other.Name {
-			return 1
-		}
-
-		if val, ok := memo[node]; ok {
-			return val
-		}
+func (n *Node) CountPaths(other *Node) (int, error) {
+	return n.graph.CountPaths(n.Name, other.Name)
+}
 
-		sum := 0
-		node.ForEachChild(func(child *Node) {
-			sum += loop(child)
-		})
+type ParseError struct {
+	Line   int
+	Column int
+	Err    error
+}
 
-		memo[node] = sum
-		return sum
-	}
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
 
-	return loop(n)
+func (e *ParseError) Unwrap() error {
+	return e.Err
 }
 
 type Parser struct {
-	state   ParserState
-	builder strings.Builder
-	curr    *Node
-	seen    map[string]*Node
+	MergeDuplicates bool
+
+	seen     map[string]*Node
+	graph    *graph.Digraph[string]
+	line     int
+	defined  map[string]int
+	children map[string]bool
 }
 
 func NewParser() *Parser {
 	return &Parser{
-		state:   readingName,
-		builder: strings.Builder{},
-		seen:    make(map[string]*Node),
+		seen:     make(map[string]*Node),
+		graph:    graph.New[string](),
+		defined:  make(map[string]int),
+		children: make(map[string]bool),
 	}
 }
 
+func (p *Parser) Graph() *graph.Digraph[string] {
+	return p.graph
+}
+
 func (p *Parser) GetNode(name string) (*Node, error) {
 	if node, ok := p.seen[name]; ok {
 		return node, nil
@@ -236,144 +326,134 @@ func (p *Parser) GetExit() (*Node, error) {
 	return exit, nil
 }
 
+func (p *Parser) Undefined(sinks ...string) []string {
+	var names []string
+	for _, name := range p.graph.Nodes() {
+		if _, ok := p.defined[name]; !ok && !slices.Contains(sinks, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 func (p *Parser) Deserialize(s string) (*Node, error) {
-	p.clearCurrent()
-	p.state = readingName
+	p.line++
+	clear(p.children)
 
-	for _, r := range s {
-		err := p.parse(r)
-		if err != nil {
-			return nil, err
-		}
+	s = strings.TrimRight(s, " \t\r")
+	if trimmed := strings.TrimSpace(s); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return nil, nil
 	}
 
-	if p.state == readingChild {
-		err := p.createChild()
-		if err != nil {
-			return nil, err
-		}
+	sc := lex.New(s)
 
-		curr, err := p.getCurrent()
-		if err != nil {
-			return nil, err
-		}
+	if sc.Peek() == ':' {
+		return nil, p.errorAt(sc.Col(), fmt.Errorf("missing node name"))
+	}
+	col := sc.Col()
+	name, err := scanName(sc)
+	if err != nil {
+		return nil, p.lexError(err)
+	}
+	if err := sc.Expect(':'); err != nil {
+		return nil, p.lexError(err)
+	}
 
-		return curr, nil
+	parent, err := p.define(name)
+	if err != nil {
+		return nil, p.errorAt(col, err)
 	}
 
-	return nil, fmt.Errorf("invalid state")
-}
+	children := 0
+	for {
+		sc.While(isSpace)
+		if sc.Done() {
+			break
+		}
 
-func (p *Parser) parse(r rune) error {
-	switch r {
-	case ':':
-		err := p.setCurrent()
+		col := sc.Col()
+		name, err := scanName(sc)
 		if err != nil {
-			return err
+			return nil, p.lexError(err)
 		}
-	case ' ':
-		switch p.state {
-		case readingChild:
-			err := p.createChild()
-			if err != nil {
-				return err
-			}
-		case seekingChild:
-		default:
-			return fmt.Errorf("invalid character '%c'", r)
-		}
-	default:
-		p.writeRune(r)
-		if p.state == seekingChild {
-			p.state = readingChild
+		if err := p.addChild(parent, name); err != nil {
+			return nil, p.errorAt(col, err)
 		}
+		children++
 	}
 
-	return nil
-}
-
-func (p *Parser) writeRune(r rune) {
-	p.builder.WriteRune(r)
-}
+	if children == 0 {
+		return nil, p.errorAt(sc.Col(), fmt.Errorf("invalid state"))
+	}
 
-func (p *Parser) getString() string {
-	return p.builder.String()
+	return parent, nil
 }
 
-func (p *Parser) resetString() {
-	p.builder.Reset()
+func (p *Parser) errorAt(col int, err error) error {
+	return &ParseError{Line: p.line, Column: col, Err: err}
 }
 
-func (p *Parser) createChild() error {
-	if p.state != readingChild {
-		return fmt.Errorf("invalid state")
-	}
-
-	parent, err := p.getCurrent()
-	if err != nil {
+func (p *Parser) lexError(err error) error {
+	var lexErr *lex.Error
+	if !errors.As(err, &lexErr) {
 		return err
 	}
-
-	name := p.getString()
-
-	if parent.Name == name {
-		return fmt.Errorf("curr cannot be own parent")
+	if errors.Is(err, lex.ErrEOF) {
+		return p.errorAt(lexErr.Col, fmt.Errorf("unexpected end of line"))
 	}
+	return p.errorAt(lexErr.Col, fmt.Errorf("invalid character '%c'", lexErr.Rune))
+}
 
-	var child *Node
-	if _, ok := p.seen[name]; !ok {
-		child = NewNode(name)
-		p.seen[name] = child
-	} else {
-		child = p.seen[name]
+func (p *Parser) node(name string) *Node {
+	if node, ok := p.seen[name]; ok {
+		return node
 	}
+	node := NewNode(p.graph, name)
+	p.seen[name] = node
+	return node
+}
 
-	err = parent.AddChild(child)
-	if err != nil {
-		return err
+func (p *Parser) define(name string) (*Node, error) {
+	if line, ok := p.defined[name]; ok && !p.MergeDuplicates {
+		return nil, fmt.Errorf("node %s already defined on line %d", name, line)
 	}
-
-	p.state = seekingChild
-	p.resetString()
-
-	return nil
+	if _, ok := p.defined[name]; !ok {
+		p.defined[name] = p.line
+	}
+	return p.node(name), nil
 }
 
-func (p *Parser) setCurrent() error {
-	if p.state != readingName || p.curr != nil {
-		return fmt.Errorf("invalid state")
+func (p *Parser) addChild(parent *Node, name string) error {
+	if parent.Name == name {
+		return fmt.Errorf("curr cannot be own parent")
 	}
 
-	name := p.getString()
+	child := p.node(name)
 
-	if ptr, ok := p.seen[name]; ok {
-		p.curr = ptr
-	} else {
-		p.curr = NewNode(name)
-		p.seen[name] = p.curr
+	merged := p.MergeDuplicates && !p.children[name] && p.graph.HasEdge(parent.Name, name)
+	if !merged {
+		if err := parent.AddChild(child); err != nil {
+			return err
+		}
 	}
-
-	p.state = seekingChild
-	p.resetString()
+	p.children[name] = true
 
 	return nil
 }
 
-func (p *Parser) getCurrent() (*Node, error) {
-	if p.curr == nil {
-		return nil, fmt.Errorf("current not found")
-	}
-	return p.curr, nil
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t'
 }
 
-func (p *Parser) clearCurrent() {
-	p.curr = nil
+func isNameRune(r rune) bool {
+	return r != ':' && !isSpace(r)
 }
 
-type ParserState int
-
-const (
-	readingName ParserState = iota
-	seekingChild
-	readingChild
-)
+func scanName(sc *lex.Scanner) (string, error) {
+	name := sc.While(isNameRune)
+	if name == "" {
+		return "", sc.Unexpected()
+	}
+	return name, nil
+}
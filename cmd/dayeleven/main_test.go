@@ -7,6 +7,7 @@ import (
 	"os"
 	"reflect"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -36,7 +37,7 @@ func TestParser_Deserialize(t *testing.T) {
 		{
 			name:  "Childless",
 			s:     "aaa: ",
-			error: errors.New("invalid state"),
+			error: errors.New("line 1, column 5: invalid state"),
 		},
 		{
 			name:     "Average Family",
@@ -48,12 +49,41 @@ func TestParser_Deserialize(t *testing.T) {
 		{
 			name:  "Parent Of Self",
 			s:     "aaa: aaa",
-			error: errors.New("curr cannot be own parent"),
+			error: errors.New("line 1, column 6: curr cannot be own parent"),
+		},
+		{
+			name:     "Trailing Whitespace",
+			s:        "aaa: bbb\tccc  \t\r",
+			expected: "aaa",
+			children: []string{"bbb", "ccc"},
+			error:    nil,
+		},
+		{
+			name:  "Missing Name",
+			s:     ": bbb",
+			error: errors.New("line 1, column 1: missing node name"),
+		},
+		{
+			name:  "Leading Space",
+			s:     " aaa: bbb",
+			error: errors.New("line 1, column 1: invalid character ' '"),
+		},
+		{
+			name:     "Punctuation In Names",
+			s:        "a.b/c: x+1 ñode [y]",
+			expected: "a.b/c",
+			children: []string{"[y]", "x+1", "ñode"},
+			error:    nil,
+		},
+		{
+			name:  "Colon In Child",
+			s:     "aaa: b:c",
+			error: errors.New("line 1, column 7: invalid character ':'"),
 		},
 		{
 			name:  "Duplicate Children",
 			s:     "aaa: bbb bbb",
-			error: errors.New("child bbb already exists"),
+			error: errors.New("line 1, column 10: child bbb already exists"),
 		},
 	}
 
@@ -83,6 +113,76 @@ func TestParser_Deserialize(t *testing.T) {
 	}
 }
 
+func TestParser_Deserialize_Lines(t *testing.T) {
+	data := []string{
+		"# device list",
+		"aaa: bbb ccc",
+		"",
+		"   ",
+		"bbb: ddd",
+		"aaa: ddd ccc",
+	}
+
+	tests := []struct {
+		name     string
+		merge    bool
+		children []string
+		error    string
+	}{
+		{
+			name:  "Duplicate Parent",
+			error: "line 6, column 1: node aaa already defined on line 2",
+		},
+		{
+			name:     "Merged Parent",
+			merge:    true,
+			children: []string{"bbb", "ccc", "ddd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser()
+			p.MergeDuplicates = tt.merge
+
+			var err error
+			for _, d := range data {
+				if _, err = p.Deserialize(d); err != nil {
+					break
+				}
+			}
+
+			if tt.error != "" {
+				var parseErr *ParseError
+				if err == nil || err.Error() != tt.error || !errors.As(err, &parseErr) {
+					t.Fatalf("Deserialize() error = %v, wantErr %v", err, tt.error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Deserialize() error = %v", err)
+			}
+
+			node, err := p.GetNode("aaa")
+			if err != nil {
+				t.Fatalf("GetNode() error = %v", err)
+			}
+			names := node.collectChildNames()
+			slices.Sort(names)
+			if !reflect.DeepEqual(names, tt.children) {
+				t.Errorf("Deserialize() = %v, want %v", names, tt.children)
+			}
+
+			if undefined := p.Undefined(); !reflect.DeepEqual(undefined, []string{"ccc", "ddd"}) {
+				t.Errorf("Undefined() = %v, want %v", undefined, []string{"ccc", "ddd"})
+			}
+			if undefined := p.Undefined("ddd"); !reflect.DeepEqual(undefined, []string{"ccc"}) {
+				t.Errorf("Undefined(%q) = %v, want %v", "ddd", undefined, []string{"ccc"})
+			}
+		})
+	}
+}
+
 func TestParser_Deserialize_Cycle(t *testing.T) {
 	data := []string{
 		"you: aaa",
@@ -112,6 +212,147 @@ func TestParser_Deserialize_Cycle(t *testing.T) {
 	}
 }
 
+func TestNode_CountPaths_Cycle(t *testing.T) {
+	data := []string{
+		"you: aaa",
+		"aaa: bbb out",
+		"bbb: aaa",
+	}
+
+	p := NewParser()
+	for _, d := range data {
+		if _, err := p.Deserialize(d); err != nil {
+			t.Fatalf("Deserialize() error = %v", err)
+		}
+	}
+
+	root, err := p.GetRoot()
+	if err != nil {
+		t.Fatalf("GetRoot() error = %v", err)
+	}
+	exit, err := p.GetExit()
+	if err != nil {
+		t.Fatalf("GetExit() error = %v", err)
+	}
+
+	want := "cycle detected: aaa -> bbb -> aaa"
+	if _, err := root.CountPaths(exit); err == nil || err.Error() != want {
+		t.Errorf("CountPaths() error = %v, want %v", err, want)
+	}
+}
+
+func TestCountPaths_SimpleLimit(t *testing.T) {
+	data := []string{
+		"svr: aaa bbb",
+		"aaa: bbb dac",
+		"bbb: aaa fft",
+		"dac: fft out",
+		"fft: dac out",
+	}
+
+	p := NewParser()
+	for _, d := range data {
+		if _, err := p.Deserialize(d); err != nil {
+			t.Fatalf("Deserialize() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		via      []string
+		limit    int
+		modulus  uint64
+		expected string
+		err      string
+	}{
+		{
+			name: "Cycle Without Limit",
+			err:  "cycle detected: aaa -> bbb -> aaa",
+		},
+		{
+			name:     "All Simple Paths",
+			limit:    100,
+			expected: "8",
+		},
+		{
+			name:     "Simple Paths Via Stops",
+			via:      []string{"dac", "fft"},
+			limit:    100,
+			expected: "4",
+		},
+		{
+			name:     "Simple Paths Modulo",
+			limit:    100,
+			modulus:  5,
+			expected: "3",
+		},
+		{
+			name:  "Limit Exceeded",
+			limit: 3,
+			err:   "more than 3 simple paths explored from svr to out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := countOptions{simpleLimit: tt.limit, modulus: tt.modulus}
+			count, err := countPaths(p.Graph(), "svr", "out", tt.via, opts)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("countPaths() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("countPaths() error = %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("countPaths() = %v, want %v", count, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountPaths_Modes(t *testing.T) {
+	p := NewParser()
+	prev := "svr"
+	for layer := range 70 {
+		next := []string{fmt.Sprintf("a%02d", layer), fmt.Sprintf("b%02d", layer)}
+		for _, node := range strings.Fields(prev) {
+			if _, err := p.Deserialize(node + ": " + strings.Join(next, " ")); err != nil {
+				t.Fatalf("Deserialize() error = %v", err)
+			}
+		}
+		prev = strings.Join(next, " ")
+	}
+	for _, node := range strings.Fields(prev) {
+		if _, err := p.Deserialize(node + ": out"); err != nil {
+			t.Fatalf("Deserialize() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		opts     countOptions
+		expected string
+	}{
+		{"Big", countOptions{big: true}, "1180591620717411303424"},
+		{"Modulo", countOptions{modulus: 1_000_000_007}, "270016253"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := countPaths(p.Graph(), "svr", "out", nil, tt.opts)
+			if err != nil {
+				t.Fatalf("countPaths() error = %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("countPaths() = %v, want %v", count, tt.expected)
+			}
+		})
+	}
+}
+
 func TestNode_CountPaths_ExampleInput(t *testing.T) {
 	filepath := "../../testdata/dayeleven/example_part_one.txt"
 	parser, err := readExampleFile(filepath)
@@ -129,37 +370,50 @@ func TestNode_CountPaths_ExampleInput(t *testing.T) {
 		t.Fatalf("GetExit() error = %v, wantErr %v", err, nil)
 	}
 
-	count := root.CountPaths(exit)
+	count, err := root.CountPaths(exit)
+	if err != nil {
+		t.Fatalf("CountPaths() error = %v", err)
+	}
 	if count != 5 {
 		t.Errorf("CountPaths() = %v, want %v", count, 5)
 	}
 }
 
-func TestNode_CountPathsWithStops_ExampleInput(t *testing.T) {
+func TestCountPathsVia_ExampleInput(t *testing.T) {
 	filepath := "../../testdata/dayeleven/example_part_two.txt"
 	parser, err := readExampleFile(filepath)
 	if err != nil {
 		t.Fatalf("error reading file: %v", err)
 	}
 
-	server, err := parser.GetNode("svr")
+	count, err := parser.Graph().CountPathsVia("svr", "out", []string{"dac", "fft"}, false)
 	if err != nil {
-		t.Fatalf("GetNode() error = %v, wantErr %v", err, nil)
+		t.Fatalf("CountPathsVia() error = %v", err)
 	}
-
-	exit, err := parser.GetExit()
-	if err != nil {
-		t.Fatalf("GetExit() error = %v, wantErr %v", err, nil)
+	if count != 2 {
+		t.Errorf("CountPathsVia() = %v, want %v", count, 2)
 	}
+}
 
-	stops, err := NewStops(parser)
-	if err != nil {
-		t.Fatalf("error getting stops: %v", err)
+func TestParseWaypoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected []string
+	}{
+		{"Empty", "", nil},
+		{"Single", "dac", []string{"dac"}},
+		{"Pair", "dac,fft", []string{"dac", "fft"}},
+		{"Spaces And Blanks", " dac, ,fft ,", []string{"dac", "fft"}},
 	}
 
-	count := countPathsWithStops(server, exit, stops)
-	if count != 2 {
-		t.Errorf("CountPaths() = %v, want %v", count, 2)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waypoints := parseWaypoints(tt.s)
+			if !reflect.DeepEqual(waypoints, tt.expected) {
+				t.Errorf("parseWaypoints() = %v, want %v", waypoints, tt.expected)
+			}
+		})
 	}
 }
 
@@ -184,3 +438,244 @@ func readExampleFile(filepath string) (*Parser, error) {
 	}
 	return parser, nil
 }
+
+func TestRenderDOT(t *testing.T) {
+	data := []string{
+		"you: aaa",
+		"svr: aaa bbb",
+		"aaa: dac",
+		"bbb: fft",
+		"dac: out",
+		"fft: out",
+		"zzz: you",
+	}
+
+	p := NewParser()
+	for _, d := range data {
+		if _, err := p.Deserialize(d); err != nil {
+			t.Fatalf("Deserialize() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		opts     dotOptions
+		contains []string
+		excludes []string
+		err      string
+	}{
+		{
+			name: "Highlights",
+			opts: dotOptions{
+				sources:   []string{"you", "svr"},
+				sinks:     []string{"out"},
+				waypoints: []string{"dac", "fft"},
+			},
+			contains: []string{
+				`"svr" [fillcolor="#a9dfbf", style="filled"];`,
+				`"out" [fillcolor="#f5b7b1", style="filled"];`,
+				`"dac" [fillcolor="#aed6f1", style="filled"];`,
+				`"zzz";`,
+				`"svr" -> "bbb";`,
+			},
+		},
+		{
+			name: "Counts",
+			opts: dotOptions{countsTo: "out"},
+			contains: []string{
+				`"svr" [label="svr\n2"];`,
+				`"zzz" [label="zzz\n1"];`,
+			},
+		},
+		{
+			name:     "Between",
+			opts:     dotOptions{between: "svr:fft"},
+			contains: []string{`"svr" -> "bbb";`, `"bbb" -> "fft";`},
+			excludes: []string{`"aaa"`, `"out"`, `"you"`},
+		},
+		{
+			name: "Malformed Between",
+			opts: dotOptions{between: "svr"},
+			err:  `invalid subgraph "svr", want from:to`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			err := renderDOT(&buf, p.Graph(), tt.opts)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("renderDOT() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("renderDOT() error = %v", err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(buf.String(), c) {
+					t.Errorf("renderDOT() missing %q in\n%s", c, buf.String())
+				}
+			}
+			for _, e := range tt.excludes {
+				if strings.Contains(buf.String(), e) {
+					t.Errorf("renderDOT() unexpectedly contains %q in\n%s", e, buf.String())
+				}
+			}
+		})
+	}
+
+	if p.Graph().NodeAttrs("svr") != nil {
+		t.Errorf("renderDOT() modified the parsed graph")
+	}
+}
+
+func TestRenderDOT_CycleCounts(t *testing.T) {
+	data := []string{
+		"svr: aaa fft",
+		"aaa: bbb",
+		"bbb: aaa out",
+		"fft: out",
+	}
+
+	p := NewParser()
+	for _, d := range data {
+		if _, err := p.Deserialize(d); err != nil {
+			t.Fatalf("Deserialize() error = %v", err)
+		}
+	}
+
+	var buf strings.Builder
+	if err := renderDOT(&buf, p.Graph(), dotOptions{countsTo: "out"}); err != nil {
+		t.Fatalf("renderDOT() error = %v", err)
+	}
+
+	for _, c := range []string{
+		`"fft" [label="fft\n1"];`,
+		`"out" [label="out\n1"];`,
+		`"svr" [label="svr\n(cycle)"];`,
+		`"aaa" [label="aaa\n(cycle)"];`,
+		`"bbb" [label="bbb\n(cycle)"];`,
+	} {
+		if !strings.Contains(buf.String(), c) {
+			t.Errorf("renderDOT() missing %q in\n%s", c, buf.String())
+		}
+	}
+}
+
+func TestExplainPaths(t *testing.T) {
+	data := []string{
+		"svr: aaa bbb",
+		"aaa: out",
+		"bbb: ccc",
+		"ccc: out",
+	}
+
+	p := NewParser()
+	for _, d := range data {
+		if _, err := p.Deserialize(d); err != nil {
+			t.Fatalf("Deserialize() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		opts     explainOptions
+		expected string
+	}{
+		{
+			name: "List",
+			opts: explainOptions{list: 1},
+			expected: "First 1 paths from svr to out:\n" +
+				"  svr -> aaa -> out (2 hops)\n",
+		},
+		{
+			name: "Shortest",
+			opts: explainOptions{shortest: 5},
+			expected: "5 shortest paths from svr to out:\n" +
+				"  svr -> aaa -> out (2 hops)\n" +
+				"  svr -> bbb -> ccc -> out (3 hops)\n",
+		},
+		{
+			name:     "Nothing Requested",
+			opts:     explainOptions{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			if err := explainPaths(&buf, p.Graph(), "svr", "out", tt.opts); err != nil {
+				t.Fatalf("explainPaths() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("explainPaths() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+
+	var buf strings.Builder
+	if err := explainPaths(&buf, p.Graph(), "svr", "out", explainOptions{sample: 20, seed: 7}); err != nil {
+		t.Fatalf("explainPaths() error = %v", err)
+	}
+	if n := strings.Count(buf.String(), " hops)\n"); n != 20 {
+		t.Errorf("explainPaths() sampled %v paths, want %v", n, 20)
+	}
+}
+
+func TestRunQuery(t *testing.T) {
+	data := []string{
+		"svr: aaa bbb",
+		"aaa: ccc",
+		"bbb: ccc",
+		"ccc: dac fft",
+		"dac: out",
+		"fft: out",
+		"you: fft",
+	}
+
+	p := NewParser()
+	for _, d := range data {
+		if _, err := p.Deserialize(d); err != nil {
+			t.Fatalf("Deserialize() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		args     []string
+		expected string
+		err      string
+	}{
+		{"Ancestors", "ancestors", []string{"fft"}, "svr\naaa\nbbb\nccc\nyou\n", ""},
+		{"Descendants", "descendants", []string{"ccc"}, "dac\nfft\nout\n", ""},
+		{"Dominators", "dominators", nil, "svr\nccc\nout\n", ""},
+		{"Articulation", "articulation", nil, "ccc\n", ""},
+		{"On Path", "onpath", nil, "svr\naaa\nbbb\nccc\ndac\nfft\nout\n", ""},
+		{"Missing Node", "ancestors", nil, "", "ancestors needs a node argument"},
+		{"Unknown Node", "descendants", []string{"zzz"}, "", "error running descendants: node zzz not found"},
+		{"Unknown Query", "siblings", nil, "", "unknown query: siblings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			err := runQuery(&buf, tt.query, p.Graph(), "svr", "out", tt.args)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("runQuery() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runQuery() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("runQuery() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"adventofcode2025/internal/graph"
+)
+
+type query struct {
+	args int
+	run  func(g *graph.Digraph[string], from, to string, args []string) ([]string, error)
+}
+
+var queries = map[string]query{
+	"ancestors": {
+		args: 1,
+		run: func(g *graph.Digraph[string], _, _ string, args []string) ([]string, error) {
+			return g.Ancestors(args[0])
+		},
+	},
+	"descendants": {
+		args: 1,
+		run: func(g *graph.Digraph[string], _, _ string, args []string) ([]string, error) {
+			return g.Descendants(args[0])
+		},
+	},
+	"dominators": {
+		run: func(g *graph.Digraph[string], from, to string, _ []string) ([]string, error) {
+			return g.Dominators(from, to)
+		},
+	},
+	"articulation": {
+		run: func(g *graph.Digraph[string], from, to string, _ []string) ([]string, error) {
+			return g.ArticulationPoints(from, to)
+		},
+	},
+	"onpath": {
+		run: func(g *graph.Digraph[string], from, to string, _ []string) ([]string, error) {
+			return g.OnPath(from, to)
+		},
+	},
+}
+
+func queryNames() []string {
+	names := make([]string, 0, len(queries))
+	for name := range queries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func runQuery(w io.Writer, name string, g *graph.Digraph[string], from, to string, args []string) error {
+	q, ok := queries[name]
+	if !ok {
+		return fmt.Errorf("unknown query: %s", name)
+	}
+	if len(args) < q.args {
+		return fmt.Errorf("%s needs a node argument", name)
+	}
+
+	nodes, err := q.run(g, from, to, args)
+	if err != nil {
+		return fmt.Errorf("error running %s: %w", name, err)
+	}
+
+	for _, node := range nodes {
+		if _, err := fmt.Fprintln(w, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
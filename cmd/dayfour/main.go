@@ -1,111 +1,151 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
-	"adventofcode2025/internal/mathutils"
+	"adventofcode2025/internal/automaton"
+	"adventofcode2025/internal/viz"
 )
 
 func main() {
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: go run . <path/to/input/file> <retries>")
+	vizKind := flag.String("viz", "", "render each generation as ascii, gif or png")
+	vizOut := flag.String("viz-out", "", "output file (ascii, gif) or directory (png) for --viz")
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		fmt.Println("Usage: go run . [--viz ascii|gif|png] [--viz-out path] <path/to/input/file> <retries>")
 		os.Exit(1)
 	}
 
-	bytes, err := os.ReadFile(os.Args[1])
+	bytes, err := os.ReadFile(flag.Arg(0))
 	if err != nil {
 		fmt.Printf("Error reading file: %v\n", err)
 		os.Exit(1)
 	}
 
-	retries := os.Args[2] == "true"
+	retries := flag.Arg(1) == "true"
 
 	contents := string(bytes)
 
 	cols := getCols(contents)
 	rolls := getRolls(contents, cols)
 
-	movable := 0
-	try := true
-
-	for try {
-		try = false
-		removed := make([]int, 0)
-
-		for r, exists := range rolls {
-			if !exists {
-				continue
-			}
+	generations := 1
+	if retries {
+		generations = 0
+	}
 
-			adj := getAdjacent(r, cols, rolls)
+	a := automaton.New(rolls, automaton.Moore, removeAccessible, automaton.Synchronous)
 
-			if adj < 4 {
-				movable++
-				removed = append(removed, r)
-				try = true
-			}
+	var renderer viz.Renderer
+	var renderErr func() error
+	if *vizKind != "" {
+		renderer, err = viz.New(*vizKind, *vizOut)
+		if err != nil {
+			fmt.Printf("Error creating renderer: %v\n", err)
+			os.Exit(1)
 		}
-
-		if !retries {
-			break
+		renderErr, err = observe(a, renderer)
+		if err != nil {
+			fmt.Printf("Error rendering frame: %v\n", err)
+			os.Exit(1)
 		}
+	}
+
+	res := a.Run(generations)
 
-		for _, r := range removed {
-			rolls[r] = false
+	if renderer != nil {
+		if err := renderErr(); err != nil {
+			fmt.Printf("Error rendering frame: %v\n", err)
+			os.Exit(1)
+		}
+		if err := renderer.Close(); err != nil {
+			fmt.Printf("Error closing renderer: %v\n", err)
+			os.Exit(1)
 		}
 	}
 
-	fmt.Printf("Movable Rolls: %v\n", movable)
+	fmt.Printf("Movable Rolls: %v\n", res.TotalChanged())
 }
 
-func getAdjacent(r int, cols int, rolls map[int]bool) int {
-	adj := 0
+func observe(a *automaton.Automaton[bool], renderer viz.Renderer) (func() error, error) {
+	prev := a.Grid().Clone()
+	if err := renderer.Render(toFrame(prev, prev)); err != nil {
+		return nil, err
+	}
 
-	e := r + 1
-	w := r - 1
-	n := r - cols
-	s := r + cols
-	ne := n + 1
-	nw := n - 1
-	se := s + 1
-	sw := s - 1
+	var renderErr error
+	a.Observe(func(g *automaton.Grid[bool], _ automaton.Stats[bool]) {
+		if renderErr != nil {
+			return
+		}
+		if err := renderer.Render(toFrame(prev, g)); err != nil {
+			renderErr = err
+			return
+		}
+		copy(prev.Cells, g.Cells)
+	})
 
-	left := mathutils.Mod(r, cols) != 0
-	right := mathutils.Mod(r+1, cols) != 0
+	return func() error { return renderErr }, nil
+}
 
-	if right && rolls[e] {
-		adj++
-	}
-	if left && rolls[w] {
-		adj++
-	}
-	if rolls[n] {
-		adj++
-	}
-	if rolls[s] {
-		adj++
+func toFrame(prev, curr *automaton.Grid[bool]) viz.Frame {
+	frame := make(viz.Frame, curr.Rows)
+	for i := range curr.Rows {
+		var b strings.Builder
+		for j := range curr.Cols {
+			switch {
+			case curr.Get(i, j):
+				b.WriteByte('@')
+			case prev.Get(i, j):
+				b.WriteByte('x')
+			default:
+				b.WriteByte('.')
+			}
+		}
+		frame[i] = b.String()
 	}
-	if right && rolls[ne] {
-		adj++
+	return frame
+}
+
+func removeAccessible(roll bool, neighbours []bool) bool {
+	if !roll {
+		return false
 	}
-	if left && rolls[nw] {
-		adj++
+
+	adj := 0
+	for _, n := range neighbours {
+		if n {
+			adj++
+		}
 	}
-	if right && rolls[se] {
-		adj++
+
+	return adj >= 4
+}
+
+func getRolls(contents string, cols int) *automaton.Grid[bool] {
+	rows := 0
+	j := 0
+
+	for _, r := range contents {
+		if r == '\n' {
+			rows++
+			j = 0
+			continue
+		}
+		j++
 	}
-	if left && rolls[sw] {
-		adj++
+	if j > 0 {
+		rows++
 	}
-	return adj
-}
 
-func getRolls(contents string, cols int) map[int]bool {
-	rolls := make(map[int]bool)
+	rolls := automaton.NewGrid[bool](rows, cols)
 
 	i := 0
-	j := 0
+	j = 0
 
 	for _, r := range contents {
 		if r == '\n' {
@@ -114,8 +154,8 @@ func getRolls(contents string, cols int) map[int]bool {
 			continue
 		}
 
-		if r == '@' {
-			rolls[i*cols+j] = true
+		if r == '@' && j < cols {
+			rolls.Set(i, j, true)
 		}
 
 		j++
@@ -1,243 +1,170 @@
 package main
 
 import (
-	"adventofcode2025/internal/refutils"
+	"adventofcode2025/internal/geometry"
+	"adventofcode2025/internal/input"
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
-	"math"
 	"os"
+	"strings"
 )
 
-const TWOPI = 2 * math.Pi
-
-type Tile struct {
-	X float64
-	Y float64
-}
-
-func (t Tile) computeArea(other Tile) float64 {
-	return (math.Abs(t.X-other.X) + 1) * (math.Abs(t.Y-other.Y) + 1)
-}
-
-func (t Tile) angle(curr, next *Tile) float64 {
-
-	x1 := curr.X - t.X
-	y1 := curr.Y - t.Y
-	x2 := next.X - t.X
-	y2 := next.Y - t.Y
-
-	theta1 := math.Atan2(y1, x1)
-	theta2 := math.Atan2(y2, x2)
-	dtheta := theta2 - theta1
-
-	for dtheta > math.Pi {
-		dtheta -= TWOPI
-	}
-
-	for dtheta < -math.Pi {
-		dtheta += TWOPI
-	}
-
-	return dtheta
+func tileArea(a, b geometry.Point) int {
+	return (abs(a.X-b.X) + 1) * (abs(a.Y-b.Y) + 1)
 }
 
-func (t Tile) pointOnAxisAlignedSegment(a, b *Tile) bool {
-	if a.X == b.X && t.X == a.X {
-		if a.Y < b.Y {
-			return t.Y >= a.Y && t.Y <= b.Y
-		}
-		return t.Y >= b.Y && t.Y <= a.Y
+func abs(v int) int {
+	if v < 0 {
+		return -v
 	}
-	if a.Y == b.Y && t.Y == a.Y {
-		if a.X < b.X {
-			return t.X >= a.X && t.X <= b.X
-		}
-		return t.X >= b.X && t.X <= a.X
-	}
-	return false
-}
-
-type Node struct {
-	Tile *Tile
-	Next *Node
-}
-
-func NewNode(tile *Tile) *Node {
-	return &Node{Tile: tile}
-}
-
-type rectBounds struct {
-	xLo float64
-	xHi float64
-	yLo float64
-	yHi float64
+	return v
 }
 
-func newRectBounds(a, b Tile) rectBounds {
-	xLo, xHi := a.X, b.X
-	if xLo > xHi {
-		xLo, xHi = xHi, xLo
-	}
-
-	yLo, yHi := a.Y, b.Y
-	if yLo > yHi {
-		yLo, yHi = yHi, yLo
-	}
-
-	return rectBounds{
-		xLo: xLo,
-		xHi: xHi,
-		yLo: yLo,
-		yHi: yHi,
+func main() {
+	if err := run(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 }
 
-func (r rectBounds) contains(t *Tile) bool {
-	return t.X > r.xLo && t.X < r.xHi && t.Y > r.yLo && t.Y < r.yHi
-}
-
-func (r rectBounds) isCrossedHorizontally(curr, next *Tile) bool {
-	if curr.Y <= r.yLo || curr.Y >= r.yHi {
-		return false
-	}
-
-	return (curr.X >= r.xHi && next.X <= r.xLo) || (curr.X <= r.xLo && next.X >= r.xHi)
-}
+func run() error {
+	normalise := flag.Bool("normalise", false, "drop collinear and repeated vertices and orient the loop counter-clockwise")
+	svgPath := flag.String("svg", "", "write the polygon and best rectangles to an SVG file")
+	flag.Parse()
 
-func (r rectBounds) isCrossedVertically(curr, next *Tile) bool {
-	if curr.X <= r.xLo || curr.X >= r.xHi {
-		return false
+	if flag.NArg() < 2 {
+		return fmt.Errorf("usage: go run . [--normalise] [--svg out.svg] <path/to/input/file> <constrain>")
 	}
 
-	return (curr.Y >= r.yHi && next.Y <= r.yLo) || (curr.Y <= r.yLo && next.Y >= r.yHi)
-}
-
-func (r rectBounds) edgeCrossesOrEnters(curr, next *Tile) bool {
-	if r.contains(curr) {
-		return true
-	}
+	constrain := flag.Arg(1) == "true"
 
-	if r.isCrossedHorizontally(curr, next) {
-		return true
+	points, lines, err := readPointsFromFile(flag.Arg(0))
+	if err != nil {
+		return err
 	}
 
-	return r.isCrossedVertically(curr, next)
-}
-
-func cornerInside(corner Tile, start *Node) bool {
-	const angleEps = 1e-7
-	angle := 0.0
-	onEdge := false
-
-	update := func(curr, next *Tile) {
-		if onEdge {
-			return
+	if *normalise {
+		normalised, kept := geometry.Normalize(points)
+		if len(points) > 0 && len(normalised) < 3 {
+			if err := validate(points, lines); err != nil {
+				return fmt.Errorf("invalid polygon: %w", err)
+			}
+			return fmt.Errorf("invalid polygon: only %d vertices remain after normalising", len(normalised))
 		}
-		if corner.pointOnAxisAlignedSegment(curr, next) {
-			onEdge = true
-			return
+		for i, k := range kept {
+			kept[i] = lines[k]
 		}
-		angle += corner.angle(curr, next)
+		points, lines = normalised, kept
 	}
 
-	for curr := start; ; curr = curr.Next {
-		update(curr.Tile, curr.Next.Tile)
-		if curr.Next == start {
-			break
-		}
+	if err := validate(points, lines); err != nil {
+		return fmt.Errorf("invalid polygon: %w", err)
 	}
 
-	return onEdge || math.Abs(angle) > (TWOPI-angleEps)
-}
-
-func (n *Node) formsValidRectangleWith(other *Node) bool {
-
-	if n.Next == other {
-		return true
+	polygon, err := geometry.NewPolygon(points)
+	if err != nil {
+		return fmt.Errorf("error building polygon: %w", err)
 	}
 
-	bounds := newRectBounds(*n.Tile, *other.Tile)
+	area, rect := findLargestRectangle(polygon, constrain)
 
-	a := Tile{X: n.Tile.X, Y: other.Tile.Y}
-	b := Tile{X: other.Tile.X, Y: n.Tile.Y}
+	fmt.Printf("The area of the largest rectange is %d\n", area)
 
-	for curr := n; ; curr = curr.Next {
-		if curr != n && curr != other && curr.Next != other {
-			if bounds.edgeCrossesOrEnters(curr.Tile, curr.Next.Tile) {
-				return false
-			}
+	if *svgPath != "" {
+		otherArea, otherRect := findLargestRectangle(polygon, !constrain)
+		unconstrained, constrained := svgRect(otherArea, otherRect), svgRect(area, rect)
+		if !constrain {
+			unconstrained, constrained = constrained, unconstrained
 		}
-
-		if curr.Next == n {
-			break
+		if err := writeSVG(*svgPath, polygon, unconstrained, constrained); err != nil {
+			return fmt.Errorf("error writing svg: %w", err)
 		}
 	}
 
-	return cornerInside(a, n) && cornerInside(b, n)
-}
-
-func main() {
-	if err := run(); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
+	return nil
 }
 
-func run() error {
-	if len(os.Args) < 3 {
-		return fmt.Errorf("usage: go run . <path/to/input/file> <constrain>")
+func findLargestRectangle(polygon *geometry.Polygon, constrain bool) (int, geometry.Rect) {
+	if !constrain {
+		return largestRectangle(polygon.Vertices(), func(geometry.Rect) bool { return true })
 	}
 
-	constrain := os.Args[2] == "true"
-
-	points, err := readPointsFromFile(os.Args[1])
+	raster, err := geometry.NewRaster(polygon)
 	if err != nil {
-		return err
+		return largestRectangle(polygon.Vertices(), polygon.ContainsRect)
 	}
+	return largestRectangle(polygon.Vertices(), raster.ContainsRect)
+}
 
-	pointPtrs := refutils.ToPointers(points)
-	nodes := createNodeRing(pointPtrs)
-	area := findLargestRectangle(nodes, constrain)
-
-	fmt.Printf("The area of the largest rectange is %d\n", int(area))
-
-	return nil
+func svgRect(area int, rect geometry.Rect) *geometry.Rect {
+	if area == 0 {
+		return nil
+	}
+	return &rect
 }
 
-func findLargestRectangle(nodes []*Node, constrain bool) float64 {
-	best := 0.0
+func largestRectangle(vertices []geometry.Point, valid func(geometry.Rect) bool) (int, geometry.Rect) {
+	best := 0
+	var rect geometry.Rect
 
-	for i := 0; i < len(nodes); i++ {
-		for j := i + 1; j < len(nodes); j++ {
-			delta := nodes[i].Tile.computeArea(*nodes[j].Tile)
+	for i := 0; i < len(vertices); i++ {
+		for j := i + 1; j < len(vertices); j++ {
+			delta := tileArea(vertices[i], vertices[j])
 			if delta > best {
-				if !constrain || nodes[i].formsValidRectangleWith(nodes[j]) {
+				candidate := geometry.NewRect(vertices[i], vertices[j])
+				if valid(candidate) {
 					best = delta
+					rect = candidate
 				}
 			}
 		}
 	}
-	return best
+	return best, rect
 }
 
-func createNodeRing(pointPtrs []*Tile) []*Node {
+func validate(points []geometry.Point, lines []int) error {
+	if len(points) == 0 {
+		return fmt.Errorf("empty input")
+	}
 
-	nodes := make([]*Node, len(pointPtrs))
-	for i, curr := range pointPtrs {
-		nodes[i] = NewNode(curr)
-		if i > 0 {
-			nodes[i-1].Next = nodes[i]
+	var errs []error
+	for _, d := range geometry.ValidateRectilinear(points) {
+		err := describeDefect(d, points, lines)
+		if d.Kind == geometry.CollinearVertex {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
 		}
+		errs = append(errs, err)
 	}
-	nodes[len(nodes)-1].Next = nodes[0]
 
-	return nodes
+	return errors.Join(errs...)
 }
 
-func readPointsFromFile(path string) ([]Tile, error) {
+func describeDefect(d geometry.Defect, points []geometry.Point, lines []int) error {
+	switch d.Kind {
+	case geometry.TooFewVertices:
+		return fmt.Errorf("need at least 3 vertices, got %d", len(points))
+	case geometry.NotAxisAligned:
+		return fmt.Errorf("line %d: %v does not share an axis with %v on line %d",
+			lines[d.Index], points[d.Index], points[d.Other], lines[d.Other])
+	case geometry.RepeatedVertex:
+		return fmt.Errorf("line %d: %v repeats the vertex on line %d",
+			lines[d.Index], points[d.Index], lines[d.Other])
+	case geometry.CollinearVertex:
+		return fmt.Errorf("line %d: %v is collinear with its neighbours",
+			lines[d.Index], points[d.Index])
+	default:
+		return fmt.Errorf("line %d: edge from %v intersects edge from %v on line %d",
+			lines[d.Index], points[d.Index], points[d.Other], lines[d.Other])
+	}
+}
+
+func readPointsFromFile(path string) ([]geometry.Point, []int, error) {
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file: %w", err)
+		return nil, nil, fmt.Errorf("error opening file: %w", err)
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
@@ -246,18 +173,25 @@ func readPointsFromFile(path string) ([]Tile, error) {
 	}()
 
 	scanner := bufio.NewScanner(file)
-	coords := make([]Tile, 0)
+	coords := make([]geometry.Point, 0)
+	lines := make([]int, 0)
+	line := 0
 	for scanner.Scan() {
-		var c Tile
-		_, err := fmt.Sscanf(scanner.Text(), "%f,%f", &c.X, &c.Y)
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var c geometry.Point
+		err := input.UnmarshalPattern(strings.TrimSpace(scanner.Text()), "{X},{Y}", &c)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing line %q: %w", scanner.Text(), err)
+			return nil, nil, fmt.Errorf("error parsing line %d %q: %w", line, scanner.Text(), err)
 		}
 		coords = append(coords, c)
+		lines = append(lines, line)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+		return nil, nil, fmt.Errorf("error reading file: %w", err)
 	}
-	return coords, nil
+	return coords, lines, nil
 }
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"adventofcode2025/internal/geometry"
+)
+
+func generatePolygon(columns int, seed int64) []geometry.Point {
+	r := rand.New(rand.NewSource(seed))
+
+	vertices := []geometry.Point{{X: 0, Y: 0}, {X: columns * 10, Y: 0}}
+	for i := columns; i > 0; i-- {
+		h := 1 + r.Intn(100_000)
+		vertices = append(vertices,
+			geometry.Point{X: i * 10, Y: h},
+			geometry.Point{X: (i - 1) * 10, Y: h},
+		)
+	}
+	return vertices
+}
+
+func TestFindLargestRectangle(t *testing.T) {
+	tests := []struct {
+		name      string
+		vertices  []geometry.Point
+		constrain bool
+		expected  int
+	}{
+		{
+			name: "Example",
+			vertices: []geometry.Point{
+				{X: 7, Y: 1}, {X: 11, Y: 1}, {X: 11, Y: 7}, {X: 9, Y: 7},
+				{X: 9, Y: 5}, {X: 2, Y: 5}, {X: 2, Y: 3}, {X: 7, Y: 3},
+			},
+			constrain: false,
+			expected:  50,
+		},
+		{
+			name: "Example Constrained",
+			vertices: []geometry.Point{
+				{X: 7, Y: 1}, {X: 11, Y: 1}, {X: 11, Y: 7}, {X: 9, Y: 7},
+				{X: 9, Y: 5}, {X: 2, Y: 5}, {X: 2, Y: 3}, {X: 7, Y: 3},
+			},
+			constrain: true,
+			expected:  24,
+		},
+		{
+			name: "Concave Pocket",
+			vertices: []geometry.Point{
+				{X: 0, Y: 0}, {X: 9, Y: 0}, {X: 9, Y: 1}, {X: 1, Y: 1},
+				{X: 1, Y: 9}, {X: 9, Y: 9}, {X: 9, Y: 10}, {X: 0, Y: 10},
+			},
+			constrain: true,
+			expected:  20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polygon, err := geometry.NewPolygon(tt.vertices)
+			if err != nil {
+				t.Fatalf("NewPolygon() error = %v", err)
+			}
+			area, _ := findLargestRectangle(polygon, tt.constrain)
+			if area != tt.expected {
+				t.Errorf("findLargestRectangle() = %v, want %v", area, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindLargestRectangle_MatchesPolygonCheck(t *testing.T) {
+	for seed := range int64(5) {
+		polygon, err := geometry.NewPolygon(generatePolygon(100, seed))
+		if err != nil {
+			t.Fatalf("NewPolygon() error = %v", err)
+		}
+
+		expected, _ := largestRectangle(polygon.Vertices(), polygon.ContainsRect)
+		area, _ := findLargestRectangle(polygon, true)
+		if area != expected {
+			t.Errorf("findLargestRectangle() = %v, want %v", area, expected)
+		}
+	}
+}
+
+func BenchmarkFindLargestRectangle(b *testing.B) {
+	small, err := geometry.NewPolygon(generatePolygon(250, 1))
+	if err != nil {
+		b.Fatalf("NewPolygon() error = %v", err)
+	}
+	large, err := geometry.NewPolygon(generatePolygon(2_499, 1))
+	if err != nil {
+		b.Fatalf("NewPolygon() error = %v", err)
+	}
+
+	for _, polygon := range []*geometry.Polygon{small, large} {
+		n := len(polygon.Vertices())
+		b.Run(fmt.Sprintf("Compressed/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				findLargestRectangle(polygon, true)
+			}
+		})
+	}
+
+	b.Run(fmt.Sprintf("Polygon/%d", len(small.Vertices())), func(b *testing.B) {
+		for b.Loop() {
+			largestRectangle(small.Vertices(), small.ContainsRect)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []geometry.Point
+		lines  []int
+		err    string
+	}{
+		{
+			name:   "Valid",
+			points: []geometry.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}},
+			lines:  []int{1, 2, 3, 4},
+		},
+		{
+			name: "Empty",
+			err:  "empty input",
+		},
+		{
+			name:   "Too Few",
+			points: []geometry.Point{{X: 0, Y: 0}, {X: 4, Y: 0}},
+			lines:  []int{1, 2},
+			err:    "need at least 3 vertices, got 2",
+		},
+		{
+			name:   "Diagonal After Blank Line",
+			points: []geometry.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 4}},
+			lines:  []int{1, 3, 4},
+			err:    "line 3: (4,0) does not share an axis with (0,4) on line 4",
+		},
+		{
+			name: "Crossing",
+			points: []geometry.Point{
+				{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 2, Y: 4},
+				{X: 2, Y: -2}, {X: 0, Y: -2},
+			},
+			lines: []int{1, 2, 3, 4, 5, 6},
+			err:   "line 1: edge from (0,0) intersects edge from (2,4) on line 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(tt.points, tt.lines)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	polygon, err := geometry.NewPolygon([]geometry.Point{
+		{X: 7, Y: 1}, {X: 11, Y: 1}, {X: 11, Y: 7}, {X: 9, Y: 7},
+		{X: 9, Y: 5}, {X: 2, Y: 5}, {X: 2, Y: 3}, {X: 7, Y: 3},
+	})
+	if err != nil {
+		t.Fatalf("NewPolygon() error = %v", err)
+	}
+
+	_, unconstrained := findLargestRectangle(polygon, false)
+	_, constrained := findLargestRectangle(polygon, true)
+
+	var buf strings.Builder
+	if err := renderSVG(&buf, polygon, &unconstrained, &constrained); err != nil {
+		t.Fatalf("renderSVG() error = %v", err)
+	}
+
+	expected := []string{
+		`<polygon fill="#dde8f4" stroke="#4a6a8a" stroke-width="1" points="548.00,68.00 932.00,68.00 932.00,644.00 740.00,644.00 740.00,452.00 68.00,452.00 68.00,260.00 548.00,260.00"/>`,
+		`<rect class="unconstrained" x="68.00" y="68.00" width="864.00" height="384.00"`,
+		`<rect class="constrained" x="68.00" y="260.00" width="672.00" height="192.00"`,
+		`<title>11,7</title>`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("renderSVG() missing %q in\n%s", e, buf.String())
+		}
+	}
+	if strings.Count(buf.String(), "<circle") != 8 {
+		t.Errorf("renderSVG() circles = %v, want %v", strings.Count(buf.String(), "<circle"), 8)
+	}
+
+	buf.Reset()
+	if err := renderSVG(&buf, polygon, &unconstrained, nil); err != nil {
+		t.Fatalf("renderSVG() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "<!-- no constrained rectangle -->") || strings.Contains(buf.String(), `class="constrained"`) {
+		t.Errorf("renderSVG() drew a missing rectangle in\n%s", buf.String())
+	}
+}
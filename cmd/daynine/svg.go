@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"adventofcode2025/internal/geometry"
+)
+
+const (
+	svgSize   = 1000.0
+	svgMargin = 20.0
+)
+
+type svgScale struct {
+	minX   int
+	minY   int
+	factor float64
+}
+
+func newSVGScale(vertices []geometry.Point) svgScale {
+	minX, minY := vertices[0].X, vertices[0].Y
+	maxX, maxY := minX, minY
+	for _, v := range vertices {
+		minX = min(minX, v.X)
+		minY = min(minY, v.Y)
+		maxX = max(maxX, v.X)
+		maxY = max(maxY, v.Y)
+	}
+
+	span := max(maxX-minX+1, maxY-minY+1)
+	return svgScale{
+		minX:   minX,
+		minY:   minY,
+		factor: (svgSize - 2*svgMargin) / float64(span),
+	}
+}
+
+func (s svgScale) x(v int) float64 {
+	return svgMargin + float64(v-s.minX)*s.factor
+}
+
+func (s svgScale) y(v int) float64 {
+	return svgMargin + float64(v-s.minY)*s.factor
+}
+
+func writeSVG(path string, polygon *geometry.Polygon, unconstrained, constrained *geometry.Rect) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	if err := renderSVG(w, polygon, unconstrained, constrained); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func renderSVG(w io.Writer, polygon *geometry.Polygon, unconstrained, constrained *geometry.Rect) error {
+	vertices := polygon.Vertices()
+	s := newSVGScale(vertices)
+	radius := max(1, min(4, s.factor/2))
+
+	if _, err := fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 %g %g\">\n", svgSize, svgSize); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "<rect width=\"100%%\" height=\"100%%\" fill=\"white\"/>\n"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, "<polygon fill=\"#dde8f4\" stroke=\"#4a6a8a\" stroke-width=\"1\" points=\""); err != nil {
+		return err
+	}
+	for i, v := range vertices {
+		sep := " "
+		if i == 0 {
+			sep = ""
+		}
+		if _, err := fmt.Fprintf(w, "%s%.2f,%.2f", sep, s.x(v.X)+s.factor/2, s.y(v.Y)+s.factor/2); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w, "\"/>"); err != nil {
+		return err
+	}
+
+	rects := []struct {
+		name   string
+		rect   *geometry.Rect
+		stroke string
+	}{
+		{"unconstrained", unconstrained, "#c03030"},
+		{"constrained", constrained, "#30a030"},
+	}
+	for _, r := range rects {
+		if r.rect == nil {
+			if _, err := fmt.Fprintf(w, "<!-- no %s rectangle -->\n", r.name); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "<rect class=\"%s\" x=\"%.2f\" y=\"%.2f\" width=\"%.2f\" height=\"%.2f\" fill=\"%s\" fill-opacity=\"0.2\" stroke=\"%s\" stroke-width=\"2\"/>\n",
+			r.name,
+			s.x(r.rect.Min.X)+s.factor/2, s.y(r.rect.Min.Y)+s.factor/2,
+			float64(r.rect.Width())*s.factor, float64(r.rect.Height())*s.factor,
+			r.stroke, r.stroke); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range vertices {
+		if _, err := fmt.Fprintf(w, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"%.2f\" fill=\"#202020\"><title>%d,%d</title></circle>\n",
+			s.x(v.X)+s.factor/2, s.y(v.Y)+s.factor/2, radius, v.X, v.Y); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
@@ -2,9 +2,20 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
-	"maps"
+	"io"
+	"math/big"
 	"os"
+	"slices"
+
+	"adventofcode2025/internal/viz"
+)
+
+const (
+	maxLineLength = 16 * 1024 * 1024
+	maxVizFrames  = 100
+	maxVizRows    = 1024
 )
 
 func main() {
@@ -15,11 +26,15 @@ func main() {
 }
 
 func run() error {
-	if len(os.Args) < 2 {
-		return fmt.Errorf("usage: go run . <path/to/input/file>")
+	vizKind := flag.String("viz", "", "render each row of the propagation as ascii, gif or png")
+	vizOut := flag.String("viz-out", "", "output file (ascii, gif) or directory (png) for --viz")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		return fmt.Errorf("usage: go run . [--viz ascii|gif|png] [--viz-out path] <path/to/input/file>")
 	}
 
-	file, err := os.Open(os.Args[1])
+	file, err := os.Open(flag.Arg(0))
 	if err != nil {
 		return fmt.Errorf("error opening file: %w", err)
 	}
@@ -29,41 +44,160 @@ func run() error {
 		}
 	}()
 
-	scanner := bufio.NewScanner(file)
+	var renderer viz.Renderer
+	if *vizKind != "" {
+		renderer, err = viz.New(*vizKind, *vizOut)
+		if err != nil {
+			return fmt.Errorf("error creating renderer: %w", err)
+		}
+	}
+
+	var rows *sampler
+	if renderer != nil {
+		rows = newSampler()
+	}
+	splits, timelines, err := solve(file, func(line []byte, beams []big.Int) error {
+		if rows != nil {
+			rows.add(line, beams)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-	beams := make(map[int]int)
+	if renderer != nil {
+		if err := renderFrames(renderer, rows.frame()); err != nil {
+			return err
+		}
+		if err := renderer.Close(); err != nil {
+			return fmt.Errorf("error closing renderer: %w", err)
+		}
+	}
+
+	fmt.Printf("Splits: %v\n", splits)
+	fmt.Printf("Timelines: %v\n", timelines)
+
+	return nil
+}
+
+func solve(r io.Reader, onRow func(line []byte, beams []big.Int) error) (int, *big.Int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+
+	var beams, next []big.Int
 	splits := 0
-	timelines := 1
+	timelines := big.NewInt(1)
 	for scanner.Scan() {
-		line := scanner.Text()
-		next := make(map[int]int)
-		maps.Copy(next, beams)
-		for i, r := range line {
-			if r == 'S' {
-				next[i] = 1
+		line := scanner.Bytes()
+		if len(line) > len(beams) {
+			beams = slices.Grow(beams, len(line)-len(beams))[:len(line)]
+			next = slices.Grow(next, len(line)-len(next))[:len(line)]
+		}
+
+		for i := range next {
+			next[i].Set(&beams[i])
+		}
+		for i, b := range line {
+			if b == 'S' {
+				next[i].SetInt64(1)
 				continue
 			}
-			if r == '^' && beams[i] > 0 {
+			if b == '^' && beams[i].Sign() > 0 {
 				splits++
-				timelines += beams[i]
-				next[i] = 0
+				timelines.Add(timelines, &beams[i])
+				next[i].SetInt64(0)
 				if i-1 >= 0 {
-					next[i-1] += beams[i]
+					next[i-1].Add(&next[i-1], &beams[i])
 				}
 				if i+1 < len(line) {
-					next[i+1] += beams[i]
+					next[i+1].Add(&next[i+1], &beams[i])
 				}
 			}
 		}
-		beams = next
+		beams, next = next, beams
+
+		if err := onRow(line, beams); err != nil {
+			return 0, nil, err
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading file: %w", err)
+		return 0, nil, fmt.Errorf("error reading file: %w", err)
 	}
 
-	fmt.Printf("Splits: %v\n", splits)
-	fmt.Printf("Timelines: %v\n", timelines)
+	return splits, timelines, nil
+}
 
+func renderFrames(renderer viz.Renderer, rows viz.Frame) error {
+	frames := min(len(rows), maxVizFrames)
+	for i := 1; i <= frames; i++ {
+		if err := renderer.Render(rows[:i*len(rows)/frames]); err != nil {
+			return fmt.Errorf("error rendering frame: %w", err)
+		}
+	}
 	return nil
 }
+
+type sampler struct {
+	rows   [][]byte
+	free   [][]byte
+	stride int
+	seen   int
+}
+
+func newSampler() *sampler {
+	return &sampler{
+		rows:   make([][]byte, 0, maxVizRows),
+		free:   make([][]byte, 0, maxVizRows/2),
+		stride: 1,
+	}
+}
+
+func (s *sampler) add(line []byte, beams []big.Int) {
+	i := s.seen
+	s.seen++
+	if i%s.stride != 0 {
+		return
+	}
+
+	if len(s.rows) == maxVizRows {
+		kept := s.rows[:0]
+		for j, row := range s.rows {
+			if j%2 == 0 {
+				kept = append(kept, row)
+			} else {
+				s.free = append(s.free, row)
+			}
+		}
+		s.rows = kept
+		s.stride *= 2
+		if i%s.stride != 0 {
+			return
+		}
+	}
+
+	var buf []byte
+	if n := len(s.free); n > 0 {
+		buf, s.free = s.free[n-1][:0], s.free[:n-1]
+	}
+	s.rows = append(s.rows, drawBeams(buf, line, beams))
+}
+
+func (s *sampler) frame() viz.Frame {
+	f := make(viz.Frame, len(s.rows))
+	for i, row := range s.rows {
+		f[i] = string(row)
+	}
+	return f
+}
+
+func drawBeams(dst, line []byte, beams []big.Int) []byte {
+	for i, b := range line {
+		if b == '.' && i < len(beams) && beams[i].Sign() > 0 {
+			b = '|'
+		}
+		dst = append(dst, b)
+	}
+	return dst
+}
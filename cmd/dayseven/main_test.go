@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"adventofcode2025/internal/viz"
+)
+
+func splitterTriangle(depth int) string {
+	width := 2*depth + 1
+	rows := []string{strings.Repeat(".", depth) + "S" + strings.Repeat(".", depth)}
+	for k := range depth {
+		row := []byte(strings.Repeat(".", width))
+		for c := depth - k; c <= depth+k; c += 2 {
+			row[c] = '^'
+		}
+		rows = append(rows, string(row), strings.Repeat(".", width))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func noop([]byte, []big.Int) error {
+	return nil
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifold  string
+		splits    int
+		timelines *big.Int
+	}{
+		{
+			name: "Example",
+			manifold: strings.Join([]string{
+				".......S.......",
+				"...............",
+				".......^.......",
+				"...............",
+				"......^.^......",
+				"...............",
+				".....^.^.^.....",
+				"...............",
+				"....^.^...^....",
+				"...............",
+				"...^.^...^.^...",
+				"...............",
+				"..^...^.....^..",
+				"...............",
+				".^.^.^.^.^...^.",
+				"...............",
+			}, "\n"),
+			splits:    21,
+			timelines: big.NewInt(40),
+		},
+		{
+			name:      "Splitter At Edge",
+			manifold:  "S..\n^..\n...",
+			splits:    1,
+			timelines: big.NewInt(2),
+		},
+		{
+			name:      "Ragged Rows",
+			manifold:  "..S\n.\n..^\n.^..\n....",
+			splits:    2,
+			timelines: big.NewInt(3),
+		},
+		{
+			name:      "Splitter Triangle",
+			manifold:  splitterTriangle(200),
+			splits:    200 * 201 / 2,
+			timelines: new(big.Int).Lsh(big.NewInt(1), 200),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splits, timelines, err := solve(strings.NewReader(tt.manifold), noop)
+			if err != nil {
+				t.Fatalf("solve() error = %v", err)
+			}
+			if splits != tt.splits {
+				t.Errorf("solve() splits = %v, want %v", splits, tt.splits)
+			}
+			if timelines.Cmp(tt.timelines) != 0 {
+				t.Errorf("solve() timelines = %v, want %v", timelines, tt.timelines)
+			}
+		})
+	}
+}
+
+func generateManifold(width, rows, depth int) []byte {
+	var buf bytes.Buffer
+	mid := width / 2
+	for r := range rows {
+		row := []byte(strings.Repeat(".", width))
+		switch {
+		case r == 0:
+			row[mid] = 'S'
+		case r%2 == 0 && r/2 <= depth:
+			k := r/2 - 1
+			for c := mid - k; c <= mid+k; c += 2 {
+				row[c] = '^'
+			}
+		}
+		buf.Write(row)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func TestSolve_Allocations(t *testing.T) {
+	small := generateManifold(101, 2_000, 40)
+	large := generateManifold(101, 20_000, 40)
+
+	measure := func(data []byte) float64 {
+		return testing.AllocsPerRun(5, func() {
+			if _, _, err := solve(bytes.NewReader(data), noop); err != nil {
+				t.Fatalf("solve() error = %v", err)
+			}
+		})
+	}
+
+	a := measure(small)
+	b := measure(large)
+
+	if b > a {
+		t.Errorf("allocations grew with row count: %v rows = %v, %v rows = %v", 2_000, a, 20_000, b)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	tests := []struct {
+		name   string
+		rows   int
+		stride int
+	}{
+		{name: "Every Row", rows: maxVizRows, stride: 1},
+		{name: "Halved", rows: maxVizRows + 1, stride: 2},
+		{name: "Halved Twice", rows: 3 * maxVizRows, stride: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSampler()
+			for i := range tt.rows {
+				s.add([]byte(strconv.Itoa(i)), nil)
+			}
+
+			frame := s.frame()
+			if len(frame) > maxVizRows {
+				t.Errorf("frame() rows = %v, want at most %v", len(frame), maxVizRows)
+			}
+			for i, row := range frame {
+				if row != strconv.Itoa(i*tt.stride) {
+					t.Fatalf("frame()[%d] = %v, want %v", i, row, i*tt.stride)
+				}
+			}
+			if last := (tt.rows - 1) / tt.stride * tt.stride; frame[len(frame)-1] != strconv.Itoa(last) {
+				t.Errorf("frame() last row = %v, want %v", frame[len(frame)-1], last)
+			}
+		})
+	}
+}
+
+func TestSampler_Allocations(t *testing.T) {
+	small := generateManifold(101, 2_000, 40)
+	large := generateManifold(101, 20_000, 40)
+
+	measure := func(data []byte) float64 {
+		return testing.AllocsPerRun(5, func() {
+			s := newSampler()
+			if _, _, err := solve(bytes.NewReader(data), func(line []byte, beams []big.Int) error {
+				s.add(line, beams)
+				return nil
+			}); err != nil {
+				t.Fatalf("solve() error = %v", err)
+			}
+		})
+	}
+
+	a := measure(small)
+	b := measure(large)
+
+	if b > a {
+		t.Errorf("allocations grew with row count: %v rows = %v, %v rows = %v", 2_000, a, 20_000, b)
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	data := generateManifold(1_001, 100_000, 400)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		if _, _, err := solve(bytes.NewReader(data), noop); err != nil {
+			b.Fatalf("solve() error = %v", err)
+		}
+	}
+}
+
+type recorder struct {
+	sizes []int
+	fail  bool
+}
+
+func (r *recorder) Render(f viz.Frame) error {
+	if r.fail {
+		return errors.New("disk full")
+	}
+	r.sizes = append(r.sizes, len(f))
+	return nil
+}
+
+func (r *recorder) Close() error {
+	return nil
+}
+
+func TestRenderFrames(t *testing.T) {
+	tests := []struct {
+		name  string
+		rows  int
+		sizes []int
+	}{
+		{name: "Every Row", rows: 3, sizes: []int{1, 2, 3}},
+		{name: "Capped", rows: 10 * maxVizFrames, sizes: []int{10, 20, 10 * maxVizFrames}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			if err := renderFrames(r, make(viz.Frame, tt.rows)); err != nil {
+				t.Fatalf("renderFrames() error = %v", err)
+			}
+			if len(r.sizes) != min(tt.rows, maxVizFrames) {
+				t.Errorf("renderFrames() frames = %v, want %v", len(r.sizes), min(tt.rows, maxVizFrames))
+			}
+			got := []int{r.sizes[0], r.sizes[1], r.sizes[len(r.sizes)-1]}
+			if !slices.Equal(got, tt.sizes) {
+				t.Errorf("renderFrames() sizes = %v, want %v", got, tt.sizes)
+			}
+		})
+	}
+
+	if err := renderFrames(&recorder{fail: true}, make(viz.Frame, 2)); err == nil || err.Error() != "error rendering frame: disk full" {
+		t.Errorf("renderFrames() error = %v, want %v", err, "error rendering frame: disk full")
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+const maxPowBits = 1 << 20
+
+type operator struct {
+	fold  func(acc, v *big.Int) (*big.Int, error)
+	right bool
+}
+
+var operators = map[string]operator{
+	"+": {fold: func(acc, v *big.Int) (*big.Int, error) {
+		return new(big.Int).Add(acc, v), nil
+	}},
+	"*": {fold: func(acc, v *big.Int) (*big.Int, error) {
+		return new(big.Int).Mul(acc, v), nil
+	}},
+	"-": {fold: func(acc, v *big.Int) (*big.Int, error) {
+		return new(big.Int).Sub(acc, v), nil
+	}},
+	"/": {fold: func(acc, v *big.Int) (*big.Int, error) {
+		if v.Sign() == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return new(big.Int).Quo(acc, v), nil
+	}},
+	"^": {right: true, fold: pow},
+	"||": {fold: func(acc, v *big.Int) (*big.Int, error) {
+		if v.Sign() < 0 {
+			return nil, fmt.Errorf("cannot concatenate negative value %v", v)
+		}
+		r, _ := new(big.Int).SetString(acc.String()+v.String(), 10)
+		return r, nil
+	}},
+	"max": {fold: func(acc, v *big.Int) (*big.Int, error) {
+		if v.Cmp(acc) > 0 {
+			return v, nil
+		}
+		return acc, nil
+	}},
+	"min": {fold: func(acc, v *big.Int) (*big.Int, error) {
+		if v.Cmp(acc) < 0 {
+			return v, nil
+		}
+		return acc, nil
+	}},
+}
+
+func pow(base, exp *big.Int) (*big.Int, error) {
+	if exp.Sign() < 0 {
+		return nil, fmt.Errorf("negative exponent %v", exp)
+	}
+	if base.CmpAbs(big.NewInt(1)) > 0 {
+		if !exp.IsInt64() || exp.Int64() > maxPowBits/int64(base.BitLen()) {
+			return nil, fmt.Errorf("%v ^ %v is too large", base, exp)
+		}
+	}
+	return new(big.Int).Exp(base, exp, nil), nil
+}
+
+func evaluate(op string, vals []int) (*big.Int, error) {
+	o, ok := operators[op]
+	if !ok {
+		return nil, fmt.Errorf("invalid op value: %v", op)
+	}
+	if len(vals) == 0 {
+		return nil, errors.New("no operands")
+	}
+
+	operands := make([]*big.Int, len(vals))
+	for i, v := range vals {
+		operands[i] = big.NewInt(int64(v))
+	}
+
+	if o.right {
+		acc := operands[len(operands)-1]
+		for i := len(operands) - 2; i >= 0; i-- {
+			var err error
+			if acc, err = o.fold(operands[i], acc); err != nil {
+				return nil, err
+			}
+		}
+		return acc, nil
+	}
+
+	acc := operands[0]
+	for _, v := range operands[1:] {
+		var err error
+		if acc, err = o.fold(acc, v); err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
+
+func formatExpr(op string, vals []int) string {
+	parts := make([]string, len(vals))
+	for i, v := range vals {
+		parts[i] = fmt.Sprint(v)
+	}
+	if strings.IndexFunc(op, unicode.IsLetter) >= 0 {
+		return op + "(" + strings.Join(parts, ", ") + ")"
+	}
+	return strings.Join(parts, " "+op+" ")
+}
@@ -2,18 +2,13 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"math/big"
 	"os"
-	"strconv"
 	"strings"
 )
 
-type Col struct {
-	Offset int
-	Op     rune
-	Grid   []string
-}
-
 func main() {
 	if err := run(); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -21,160 +16,77 @@ func main() {
 	}
 }
 
+type traversal struct {
+	label string
+	order readingOrder
+}
+
 func run() error {
-	if len(os.Args) < 2 {
-		return fmt.Errorf("usage: go run . <path/to/input/file>")
+	audit := flag.Bool("audit", false, "print each problem's expression and result")
+	orders := flag.String("order", "", "comma separated reading orders <digits>-<operands>, directions lr, rl, tb or bt (default lr-tb,tb-lr)")
+	align := flag.String("align", "none", "realign numbers within each problem before reading: none, left, right, top or bottom")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		return fmt.Errorf("usage: go run . [--audit] [--order lr-tb,tb-rl] [--align none] <path/to/input/file>")
 	}
 
-	rows, err := readFile(os.Args[1])
+	traversals, err := parseTraversals(*orders)
 	if err != nil {
-		return fmt.Errorf("error while reading file: %w", err)
+		return err
 	}
 
-	cols := initCols(rows[len(rows)-1])
-	populateGrids(rows[:len(rows)-1], cols)
-	leftJustifyGrid(cols[len(cols)-1].Grid)
+	alignment, err := parseAlignment(*align)
+	if err != nil {
+		return err
+	}
 
-	part1 := 0
-	part2 := 0
+	rows, err := readFile(flag.Arg(0))
+	if err != nil {
+		return fmt.Errorf("error while reading file: %w", err)
+	}
 
-	for _, col := range cols {
-		{
-			roworder, err := getRowOrderValues(col)
-			if err != nil {
-				return fmt.Errorf("error during row-order traversal: %w", err)
-			}
-			res, err := agg(col, roworder)
-			if err != nil {
-				return fmt.Errorf("unable to aggregate row-order values: %w", err)
-			}
-			part1 += res
-		}
+	problems, err := parseWorksheet(rows)
+	if err != nil {
+		return fmt.Errorf("error parsing worksheet: %w", err)
+	}
 
-		{
-			colorder, err := getColOrderValues(col)
+	for _, t := range traversals {
+		total := new(big.Int)
+		for i, p := range problems {
+			vals, err := p.Values(t.order, alignment)
 			if err != nil {
-				return fmt.Errorf("error during col-order traversal: %w", err)
+				return fmt.Errorf("unable to read %s values of problem %d: %w", t.order, i+1, err)
 			}
-			res, err := agg(col, colorder)
+			res, err := evaluate(p.Op, vals)
 			if err != nil {
-				return fmt.Errorf("unable to aggregate col-order values: %w", err)
+				return fmt.Errorf("unable to evaluate %s values of problem %d: %w", t.order, i+1, err)
+			}
+			if *audit {
+				fmt.Printf("Problem %d (%s): %s = %v\n", i+1, t.label, formatExpr(p.Op, vals), res)
 			}
-			part2 += res
+			total.Add(total, res)
 		}
+		fmt.Printf("%s Traversal: %v\n", t.label, total)
 	}
 
-	fmt.Printf("Row-Order Traversal: %v\n", part1)
-	fmt.Printf("Column-Order Traversal: %v\n", part2)
-
 	return nil
 }
 
-func agg(c Col, vals []int) (int, error) {
-	if c.Op != '+' && c.Op != '*' {
-		return 0, fmt.Errorf("invalid op value: %v", c.Op)
-	}
-
-	acc := 0
-	if c.Op == '*' {
-		acc = 1
-	}
-
-	for _, v := range vals {
-		if c.Op == '+' {
-			acc += v
-		} else {
-			acc *= v
-		}
-	}
-
-	return acc, nil
-}
-
-func getColOrderValues(c Col) ([]int, error) {
-	vals := make([]int, 0)
-	for i := range len(c.Grid[0]) {
-		builder := strings.Builder{}
-		for j := range len(c.Grid) {
-			builder.WriteByte(c.Grid[j][i])
-		}
-		s := builder.String()
-		val, err := trimThenAtoi(s)
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse column: %w", err)
-		}
-		vals = append(vals, val)
+func parseTraversals(s string) ([]traversal, error) {
+	if s == "" {
+		return []traversal{{"Row-Order", rowOrder}, {"Column-Order", colOrder}}, nil
 	}
-	return vals, nil
-}
 
-func getRowOrderValues(c Col) ([]int, error) {
-	vals := make([]int, 0)
-	for _, s := range c.Grid {
-		val, err := trimThenAtoi(s)
+	var traversals []traversal
+	for _, spec := range strings.Split(s, ",") {
+		order, err := parseOrder(strings.TrimSpace(spec))
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
-		}
-		vals = append(vals, val)
-	}
-
-	return vals, nil
-}
-
-func trimThenAtoi(s string) (int, error) {
-	prev := 0
-	for _, r := range s {
-		if r != ' ' {
-			curr, err := strconv.Atoi(string(r))
-			if err != nil {
-				return 0, fmt.Errorf("unable to convert to int: %w", err)
-			}
-			prev = prev*10 + curr
-		}
-	}
-	return prev, nil
-}
-
-func leftJustifyGrid(grid []string) {
-	m := len(grid[0])
-	for i := 1; i < len(grid); i++ {
-		curr := len(grid[i])
-		if curr > m {
-			m = curr
+			return nil, err
 		}
+		traversals = append(traversals, traversal{order.String(), order})
 	}
-
-	for i := range len(grid) {
-		curr := len(grid[i])
-		if curr < m {
-			padding := m - curr
-			grid[i] = grid[i] + strings.Repeat(" ", padding)
-		}
-	}
-}
-
-func populateGrids(rows []string, cols []Col) {
-	for _, row := range rows {
-		for i, col := range cols {
-			next := len(row)
-			if i != len(cols)-1 {
-				next = cols[i+1].Offset - 1
-			}
-			cols[i].Grid = append(cols[i].Grid, row[col.Offset:next])
-		}
-	}
-}
-
-func initCols(row string) []Col {
-	cols := make([]Col, 0)
-
-	for i, r := range row {
-		if r == '*' || r == '+' {
-			cols = append(cols, Col{Offset: i, Op: r})
-		}
-	}
-
-	return cols
+	return traversals, nil
 }
 
 func readFile(filepath string) ([]string, error) {
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestParseWorksheet(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected []Problem
+		rows     [][]int
+		cols     [][]int
+		err      string
+	}{
+		{
+			name: "example",
+			lines: []string{
+				"123 328  51 64 ",
+				" 45 64  387 23 ",
+				"  6 98  215 314",
+				"*   +   *   +  ",
+			},
+			expected: []Problem{
+				{Op: "*", Grid: []string{"123", " 45", "  6", "   "}},
+				{Op: "+", Grid: []string{"328", "64 ", "98 ", "   "}},
+				{Op: "*", Grid: []string{" 51", "387", "215", "   "}},
+				{Op: "+", Grid: []string{"64 ", "23 ", "314", "   "}},
+			},
+			rows: [][]int{{123, 45, 6}, {328, 64, 98}, {51, 387, 215}, {64, 23, 314}},
+			cols: [][]int{{1, 24, 356}, {369, 248, 8}, {32, 581, 175}, {623, 431, 4}},
+		},
+		{
+			name: "ragged trailing whitespace",
+			lines: []string{
+				"12  7",
+				" 3  8   ",
+				"+   *",
+			},
+			expected: []Problem{
+				{Op: "+", Grid: []string{"12", " 3", "  "}},
+				{Op: "*", Grid: []string{"7", "8", " "}},
+			},
+			rows: [][]int{{12, 3}, {7, 8}},
+			cols: [][]int{{1, 23}, {78}},
+		},
+		{
+			name: "operator inside the block",
+			lines: []string{
+				"12+",
+				"34",
+				"  5",
+			},
+			expected: []Problem{
+				{Op: "+", Grid: []string{"12 ", "34 ", "  5"}},
+			},
+			rows: [][]int{{12, 34, 5}},
+			cols: [][]int{{13, 24, 5}},
+		},
+		{
+			name: "missing operator",
+			lines: []string{
+				"1 2",
+				"3 +",
+			},
+			err: "problem 1 at columns 1-1: no operator",
+		},
+		{
+			name: "several operators",
+			lines: []string{
+				"1",
+				"+",
+				"*",
+			},
+			err: `problem 1 at columns 1-1: several operators "+" and "*"`,
+		},
+		{
+			name:  "no operands",
+			lines: []string{"1* +"},
+			err:   "problem 2 at columns 4-4: no operands",
+		},
+		{
+			name:  "empty",
+			lines: []string{"", "   "},
+			err:   "empty worksheet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseWorksheet(tt.lines)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("parseWorksheet() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWorksheet() error = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseWorksheet() = %v, want %v", result, tt.expected)
+			}
+			for i, p := range result {
+				rows, err := p.Rows()
+				if err != nil || !reflect.DeepEqual(rows, tt.rows[i]) {
+					t.Errorf("Problem %d Rows() = %v, %v, want %v", i+1, rows, err, tt.rows[i])
+				}
+				cols, err := p.Cols()
+				if err != nil || !reflect.DeepEqual(cols, tt.cols[i]) {
+					t.Errorf("Problem %d Cols() = %v, %v, want %v", i+1, cols, err, tt.cols[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		vals     []int
+		expected string
+		err      string
+	}{
+		{name: "add", op: "+", vals: []int{1, 2, 3}, expected: "6"},
+		{name: "multiply overflows int64", op: "*", vals: []int{1 << 40, 1 << 40, 3}, expected: "3626777458843887524118528"},
+		{name: "subtract is left associative", op: "-", vals: []int{10, 3, 2}, expected: "5"},
+		{name: "divide truncates", op: "/", vals: []int{100, 7, 2}, expected: "7"},
+		{name: "divide by zero", op: "/", vals: []int{1, 0}, err: "division by zero"},
+		{name: "power is right associative", op: "^", vals: []int{2, 3, 2}, expected: "512"},
+		{name: "power too large", op: "^", vals: []int{2, 1 << 30}, err: "2 ^ 1073741824 is too large"},
+		{name: "power size does not wrap", op: "^", vals: []int{1000, 1_000_000_000_000_000_000}, err: "1000 ^ 1000000000000000000 is too large"},
+		{name: "power at size limit", op: "^", vals: []int{3, maxPowBits / 2}, expected: new(big.Int).Exp(big.NewInt(3), big.NewInt(maxPowBits/2), nil).String()},
+		{name: "concatenate", op: "||", vals: []int{12, 0, 345}, expected: "120345"},
+		{name: "max", op: "max", vals: []int{3, 9, 4}, expected: "9"},
+		{name: "min", op: "min", vals: []int{3, 9, 4}, expected: "3"},
+		{name: "single operand", op: "-", vals: []int{7}, expected: "7"},
+		{name: "unknown operator", op: "%", vals: []int{1, 2}, err: "invalid op value: %"},
+		{name: "no operands", op: "+", err: "no operands"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluate(tt.op, tt.vals)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("evaluate(%q, %v) error = %v, want %v", tt.op, tt.vals, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("evaluate(%q, %v) error = %v", tt.op, tt.vals, err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("evaluate(%q, %v) = %v, want %v", tt.op, tt.vals, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatExpr(t *testing.T) {
+	tests := []struct {
+		op       string
+		vals     []int
+		expected string
+	}{
+		{op: "*", vals: []int{123, 45, 6}, expected: "123 * 45 * 6"},
+		{op: "||", vals: []int{1, 2}, expected: "1 || 2"},
+		{op: "max", vals: []int{1, 2}, expected: "max(1, 2)"},
+	}
+
+	for _, tt := range tests {
+		if result := formatExpr(tt.op, tt.vals); result != tt.expected {
+			t.Errorf("formatExpr(%q, %v) = %q, want %q", tt.op, tt.vals, result, tt.expected)
+		}
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected readingOrder
+		err      string
+	}{
+		{input: "lr-tb", expected: rowOrder},
+		{input: "tb-lr", expected: colOrder},
+		{input: "bt-rl", expected: readingOrder{digits: bottomToTop, operands: rightToLeft}},
+		{input: "lr", err: `invalid reading order "lr", want <digits>-<operands> such as lr-tb`},
+		{input: "up-lr", err: `invalid digit direction "up" in "up-lr"`},
+		{input: "tb-down", err: `invalid operand direction "down" in "tb-down"`},
+		{input: "lr-rl", err: `digit and operand directions in "lr-rl" must be perpendicular`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := parseOrder(tt.input)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("parseOrder(%q) error = %v, want %v", tt.input, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOrder(%q) error = %v", tt.input, err)
+			}
+			if result != tt.expected || result.String() != tt.input {
+				t.Errorf("parseOrder(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProblem_Values(t *testing.T) {
+	p := Problem{Op: "+", Grid: []string{"12 ", " 3 ", " 45"}}
+
+	tests := []struct {
+		order    string
+		align    alignment
+		expected []int
+	}{
+		{order: "lr-tb", align: alignNone, expected: []int{12, 3, 45}},
+		{order: "rl-tb", align: alignNone, expected: []int{21, 3, 54}},
+		{order: "lr-bt", align: alignNone, expected: []int{45, 3, 12}},
+		{order: "tb-lr", align: alignNone, expected: []int{1, 234, 5}},
+		{order: "tb-rl", align: alignNone, expected: []int{5, 234, 1}},
+		{order: "bt-lr", align: alignNone, expected: []int{1, 432, 5}},
+		{order: "tb-lr", align: alignLeft, expected: []int{134, 25}},
+		{order: "tb-lr", align: alignRight, expected: []int{14, 235}},
+		{order: "lr-tb", align: alignTop, expected: []int{125, 3, 4}},
+		{order: "lr-tb", align: alignBottom, expected: []int{2, 3, 145}},
+	}
+
+	for _, tt := range tests {
+		order, err := parseOrder(tt.order)
+		if err != nil {
+			t.Fatalf("parseOrder(%q) error = %v", tt.order, err)
+		}
+		result, err := p.Values(order, tt.align)
+		if err != nil {
+			t.Fatalf("Values(%s, %d) error = %v", tt.order, tt.align, err)
+		}
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("Values(%s, %d) = %v, want %v", tt.order, tt.align, result, tt.expected)
+		}
+	}
+}
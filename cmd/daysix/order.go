@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type direction int
+
+const (
+	leftToRight direction = iota
+	rightToLeft
+	topToBottom
+	bottomToTop
+)
+
+var directionNames = map[string]direction{
+	"lr": leftToRight,
+	"rl": rightToLeft,
+	"tb": topToBottom,
+	"bt": bottomToTop,
+}
+
+func (d direction) horizontal() bool {
+	return d == leftToRight || d == rightToLeft
+}
+
+func (d direction) reversed() bool {
+	return d == rightToLeft || d == bottomToTop
+}
+
+func (d direction) String() string {
+	switch d {
+	case leftToRight:
+		return "lr"
+	case rightToLeft:
+		return "rl"
+	case topToBottom:
+		return "tb"
+	default:
+		return "bt"
+	}
+}
+
+type readingOrder struct {
+	digits   direction
+	operands direction
+}
+
+var (
+	rowOrder = readingOrder{digits: leftToRight, operands: topToBottom}
+	colOrder = readingOrder{digits: topToBottom, operands: leftToRight}
+)
+
+func parseOrder(s string) (readingOrder, error) {
+	digits, operands, ok := strings.Cut(s, "-")
+	if !ok {
+		return readingOrder{}, fmt.Errorf("invalid reading order %q, want <digits>-<operands> such as lr-tb", s)
+	}
+
+	d, ok := directionNames[digits]
+	if !ok {
+		return readingOrder{}, fmt.Errorf("invalid digit direction %q in %q", digits, s)
+	}
+	o, ok := directionNames[operands]
+	if !ok {
+		return readingOrder{}, fmt.Errorf("invalid operand direction %q in %q", operands, s)
+	}
+	if d.horizontal() == o.horizontal() {
+		return readingOrder{}, fmt.Errorf("digit and operand directions in %q must be perpendicular", s)
+	}
+
+	return readingOrder{digits: d, operands: o}, nil
+}
+
+func (o readingOrder) String() string {
+	return o.digits.String() + "-" + o.operands.String()
+}
+
+type alignment int
+
+const (
+	alignNone alignment = iota
+	alignLeft
+	alignRight
+	alignTop
+	alignBottom
+)
+
+var alignmentNames = map[string]alignment{
+	"none":   alignNone,
+	"left":   alignLeft,
+	"right":  alignRight,
+	"top":    alignTop,
+	"bottom": alignBottom,
+}
+
+func parseAlignment(s string) (alignment, error) {
+	a, ok := alignmentNames[s]
+	if !ok {
+		return alignNone, fmt.Errorf("invalid alignment %q", s)
+	}
+	return a, nil
+}
+
+func (p Problem) Values(o readingOrder, a alignment) ([]int, error) {
+	grid := p.Grid
+	switch a {
+	case alignLeft, alignRight:
+		grid = justify(grid, a == alignRight)
+	case alignTop, alignBottom:
+		grid = transpose(justify(transpose(grid), a == alignBottom))
+	}
+
+	lines := grid
+	if !o.digits.horizontal() {
+		lines = transpose(grid)
+	}
+	if o.operands.reversed() {
+		lines = slices.Clone(lines)
+		slices.Reverse(lines)
+	}
+
+	var vals []int
+	for _, line := range lines {
+		b := []byte(line)
+		if o.digits.reversed() {
+			slices.Reverse(b)
+		}
+		v, ok, err := digits(b)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			vals = append(vals, v)
+		}
+	}
+	return vals, nil
+}
+
+func justify(lines []string, right bool) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		text := strings.TrimSpace(line)
+		pad := strings.Repeat(" ", len(line)-len(text))
+		if right {
+			out[i] = pad + text
+		} else {
+			out[i] = text + pad
+		}
+	}
+	return out
+}
+
+func transpose(lines []string) []string {
+	if len(lines) == 0 {
+		return nil
+	}
+	out := make([]string, len(lines[0]))
+	column := make([]byte, len(lines))
+	for c := range out {
+		for r, line := range lines {
+			column[r] = line[c]
+		}
+		out[c] = string(column)
+	}
+	return out
+}
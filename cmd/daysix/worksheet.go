@@ -0,0 +1,147 @@
+package main
+
+import (
+	"adventofcode2025/internal/delimited"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Problem struct {
+	Op   string
+	Grid []string
+}
+
+func (p Problem) Rows() ([]int, error) {
+	return p.Values(rowOrder, alignNone)
+}
+
+func (p Problem) Cols() ([]int, error) {
+	return p.Values(colOrder, alignNone)
+}
+
+func parseWorksheet(lines []string) ([]Problem, error) {
+	rows := make([]string, 0, len(lines))
+	width := 0
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if strings.ContainsRune(line, '\t') {
+			return nil, fmt.Errorf("tab in worksheet row %d, columns must be aligned with spaces", len(rows)+1)
+		}
+		rows = append(rows, line)
+		width = max(width, len(line))
+	}
+
+	blocks := findBlocks(rows, width)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("empty worksheet")
+	}
+
+	problems := make([]Problem, len(blocks))
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = delimited.SliceColumns(row, blocks)
+	}
+
+	for b, block := range blocks {
+		grid := make([][]byte, len(rows))
+		for i := range rows {
+			grid[i] = []byte(cells[i][b])
+		}
+
+		p, err := parseProblem(grid)
+		if err != nil {
+			return nil, fmt.Errorf("problem %d at columns %d-%d: %w", b+1, block.Start+1, block.End, err)
+		}
+		problems[b] = p
+	}
+
+	return problems, nil
+}
+
+func findBlocks(rows []string, width int) []delimited.Column {
+	var blocks []delimited.Column
+	start := -1
+	for c := 0; c <= width; c++ {
+		blank := true
+		for _, row := range rows {
+			if c < len(row) && row[c] != ' ' {
+				blank = false
+				break
+			}
+		}
+
+		switch {
+		case !blank && start < 0:
+			start = c
+		case blank && start >= 0:
+			blocks = append(blocks, delimited.Column{Start: start, End: c})
+			start = -1
+		}
+	}
+	return blocks
+}
+
+func parseProblem(grid [][]byte) (Problem, error) {
+	var p Problem
+
+	for _, row := range grid {
+		for c := 0; c < len(row); {
+			if row[c] == ' ' || isDigit(row[c]) {
+				c++
+				continue
+			}
+			end := c
+			for end < len(row) && row[end] != ' ' && !isDigit(row[end]) {
+				end++
+			}
+			if p.Op != "" {
+				return Problem{}, fmt.Errorf("several operators %q and %q", p.Op, row[c:end])
+			}
+			p.Op = string(row[c:end])
+			for i := c; i < end; i++ {
+				row[i] = ' '
+			}
+			c = end
+		}
+	}
+
+	if p.Op == "" {
+		return Problem{}, fmt.Errorf("no operator")
+	}
+
+	operands := false
+	p.Grid = make([]string, len(grid))
+	for i, row := range grid {
+		p.Grid[i] = string(row)
+		operands = operands || slices.ContainsFunc(row, isDigit)
+	}
+
+	if !operands {
+		return Problem{}, fmt.Errorf("no operands")
+	}
+
+	return p, nil
+}
+
+func digits(cells []byte) (int, bool, error) {
+	var b strings.Builder
+	for _, c := range cells {
+		if isDigit(c) {
+			b.WriteByte(c)
+		}
+	}
+	if b.Len() == 0 {
+		return 0, false, nil
+	}
+	v, err := strconv.Atoi(b.String())
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to convert to int: %w", err)
+	}
+	return v, true, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"adventofcode2025/internal/lex"
 	"adventofcode2025/internal/mathutils"
 	"bufio"
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -10,6 +12,8 @@ import (
 	"strings"
 )
 
+const structural = "[](){}, .#"
+
 func main() {
 	if err := run(); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -222,148 +226,90 @@ func calcCombs(buttons int) int {
 	return combs
 }
 
-type ParserState int
-
-const (
-	started ParserState = iota
-	bracketsOpened
-	bracketsClosed
-	parenthesesOpened
-	parenthesesClosed
-	curlyBracesOpened
-	curlyBracesClosed
-)
-
 func deserialize(s string) (*Machine, error) {
+	sc := lex.New(s)
+	m := &Machine{
+		buttons: make([]int, 0),
+		joltage: make([]int, 0),
+	}
 
-	parserState := started
-	var builder strings.Builder
-
-	configuration := 0
+	if err := sc.Expect('['); err != nil {
+		return nil, describeError(err)
+	}
 	ptr := 1
+	for sc.Peek() == '.' || sc.Peek() == '#' {
+		if sc.Next() == '#' {
+			m.configuration |= ptr
+		}
+		ptr = ptr << 1
+	}
+	if err := sc.Expect(']'); err != nil {
+		return nil, describeError(err)
+	}
 
-	buttons := make([]int, 0)
-	var button int
-
-	joltage := make([]int, 0)
+	for {
+		sc.While(isSpace)
+		if len(m.buttons) > 0 && sc.Peek() == '{' {
+			break
+		}
 
-	for i, r := range s {
-		switch r {
-		case '[':
-			if parserState == started {
-				parserState = bracketsOpened
-				continue
-			}
-			return nil, fmt.Errorf("invalid character '[' at position %d", i)
-		case ']':
-			if parserState == bracketsOpened {
-				parserState = bracketsClosed
-				continue
-			}
-			return nil, fmt.Errorf("invalid character ']' at position %d", i)
-		case '(':
-			if parserState == bracketsClosed || parserState == parenthesesClosed {
-				parserState = parenthesesOpened
-				continue
-			}
-			return nil, fmt.Errorf("invalid character '(' at position %d", i)
-		case ')':
-			if parserState == parenthesesOpened {
-				parserState = parenthesesClosed
-				res, err := tryBuilderToInt(builder)
-				if err != nil {
-					return nil, err
-				}
-				builder.Reset()
-				button = addIndicator(button, res)
-				buttons = append(buttons, button)
-				button = 0
-				continue
-			}
-			return nil, fmt.Errorf("invalid character ')' at position %d", i)
-		case '{':
-			if parserState == parenthesesClosed {
-				parserState = curlyBracesOpened
-				continue
-			}
-			return nil, fmt.Errorf("invalid character '{' at position %d", i)
-		case '}':
-			if parserState == curlyBracesOpened {
-				parserState = curlyBracesClosed
-				res, err := tryBuilderToInt(builder)
-				if err != nil {
-					return nil, err
-				}
-				builder.Reset()
-				joltage = append(joltage, res)
-				continue
-			}
-			return nil, fmt.Errorf("invalid character '{' at position %d", i)
-		case ' ':
-			if parserState == bracketsClosed || parserState == parenthesesClosed {
-				continue
-			}
-			return nil, fmt.Errorf("invalid character ' ' at position %d", i)
-		case ',':
-			switch parserState {
-			case parenthesesOpened:
-				res, err := tryBuilderToInt(builder)
-				if err != nil {
-					return nil, err
-				}
-				builder.Reset()
-				button = addIndicator(button, res)
-				continue
-			case curlyBracesOpened:
-				res, err := tryBuilderToInt(builder)
-				if err != nil {
-					return nil, err
-				}
-				builder.Reset()
-				joltage = append(joltage, res)
-				continue
-			default:
-				return nil, fmt.Errorf("invalid character ',' at position %d", i)
-			}
-		case '.':
-			if parserState == bracketsOpened {
-				ptr = ptr << 1
-				continue
-			}
-			return nil, fmt.Errorf("invalid character '.' at position %d", i)
-		case '#':
-			if parserState == bracketsOpened {
-				configuration = ptr | configuration
-				ptr = ptr << 1
-				continue
-			}
-			return nil, fmt.Errorf("invalid character '#' at position %d", i)
-		default:
-			if parserState == parenthesesOpened || parserState == curlyBracesOpened {
-				_, err := builder.WriteRune(r)
-				if err != nil {
-					return nil, err
-				}
-				continue
+		button := 0
+		err := sc.List(',', '(', ')', func() error {
+			indicator, err := sc.Int()
+			if err != nil {
+				return err
 			}
-			return nil, fmt.Errorf("invalid character at position %d", i)
+			button = addIndicator(button, indicator)
+			return nil
+		})
+		if err != nil {
+			return nil, describeError(err)
+		}
+		m.buttons = append(m.buttons, button)
+	}
+
+	err := sc.List(',', '{', '}', func() error {
+		joltage, err := sc.Int()
+		if err != nil {
+			return err
 		}
+		m.joltage = append(m.joltage, joltage)
+		return nil
+	})
+	if err != nil {
+		return nil, describeError(err)
+	}
+
+	if err := sc.ExpectEOF(); err != nil {
+		return nil, describeError(err)
 	}
 
-	return &Machine{
-		configuration: configuration,
-		buttons:       buttons,
-		joltage:       joltage,
-	}, nil
+	return m, nil
 }
 
-func tryBuilderToInt(b strings.Builder) (int, error) {
-	s := b.String()
-	i, err := strconv.Atoi(s)
-	if err != nil {
-		return 0, err
+func isSpace(r rune) bool {
+	return r == ' '
+}
+
+func describeError(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return numErr
+	}
+
+	var lexErr *lex.Error
+	if !errors.As(err, &lexErr) {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, lex.ErrEOF):
+		return fmt.Errorf("unexpected end of input at position %d", lexErr.Pos)
+	case strings.ContainsRune(structural, lexErr.Rune):
+		return fmt.Errorf("invalid character '%c' at position %d", lexErr.Rune, lexErr.Pos)
+	default:
+		return fmt.Errorf("invalid character at position %d", lexErr.Pos)
 	}
-	return i, nil
 }
 
 func addIndicator(button, indicator int) int {
@@ -44,12 +44,42 @@ func TestDeserialize(t *testing.T) {
 			expected: nil,
 			err:      errors.New("invalid character ',' at position 2"),
 		},
+		{
+			name:     "unexpected letter",
+			input:    "[#..#] x(1,3) {7,4,3,5}",
+			expected: nil,
+			err:      errors.New("invalid character at position 7"),
+		},
+		{
+			name:     "unexpected symbol",
+			input:    "[#..#] (1,3) + {7,4,3,5}",
+			expected: nil,
+			err:      errors.New("invalid character at position 13"),
+		},
+		{
+			name:     "unexpected closing brace",
+			input:    "[#..#] (1,3) } {7,4,3,5}",
+			expected: nil,
+			err:      errors.New("invalid character '}' at position 13"),
+		},
 		{
 			name:     "repeated commas",
 			input:    "[#..#] (1,,3) (2) (2,3) (0,2) (0,1) (3) {7,4,3,5}",
 			expected: nil,
 			err:      errors.New("strconv.Atoi: parsing \"\": invalid syntax"),
 		},
+		{
+			name:     "truncated joltage",
+			input:    "[#..#] (1,3) (2) {7,4",
+			expected: nil,
+			err:      errors.New("unexpected end of input at position 21"),
+		},
+		{
+			name:     "no buttons",
+			input:    "[#..#] {7,4,3,5}",
+			expected: nil,
+			err:      errors.New("invalid character '{' at position 7"),
+		},
 		{
 			name:  "non-palindromic configuration",
 			input: "[.#.#] (1,3) (2) (2,3) (0,2) (0,1) (3) {7,4,3,5}",
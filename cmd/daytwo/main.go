@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"math"
 	"os"
 	"strconv"
 
@@ -30,8 +29,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	part1 := sumInvalids(ranges, false)
-	part2 := sumInvalids(ranges, true)
+	part1, err := sumInvalids(ranges, mathutils.Exactly(2))
+	if err != nil {
+		fmt.Printf("Error summing invalid IDs: %v\n", err)
+		os.Exit(1)
+	}
+
+	part2, err := sumInvalids(ranges, mathutils.AtLeast(2))
+	if err != nil {
+		fmt.Printf("Error summing invalid IDs: %v\n", err)
+		os.Exit(1)
+	}
 
 	fmt.Printf("Part 1: %v\n", part1)
 	fmt.Printf("Part 2: %v\n", part2)
@@ -69,86 +77,21 @@ func parse(data string) ([]*mathutils.Range, error) {
 	return ranges, nil
 }
 
-func intersectWithBand(ranges []*mathutils.Range, loBand, hiBand int) []*mathutils.Range {
-	var out []*mathutils.Range
-	for _, r := range ranges {
-		lo2 := max(r.Lo, loBand)
-		hi2 := min(r.Hi, hiBand)
-		if lo2 <= hi2 {
-			out = append(out, mathutils.NewRange(lo2, hi2))
-		}
-	}
-	return out
-}
-
-func sumInvalids(ranges []*mathutils.Range, atLeastTwice bool) int {
-	if len(ranges) == 0 {
-		return 0
-	}
-
-	maxHi := 0
+func sumInvalids(ranges []*mathutils.Range, pattern mathutils.Pattern) (int, error) {
+	set := mathutils.NewRangeSet()
 	for _, r := range ranges {
-		if r.Hi > maxHi {
-			maxHi = r.Hi
-		}
-	}
-
-	if maxHi <= 0 {
-		return 0
+		set.Add(mathutils.NewRange(r.Lo, r.Hi+1))
 	}
 
-	LMax := mathutils.CountDigits(maxHi)
-	seen := make(map[int]bool)
-
-	for L := 1; L < LMax+1; L++ {
-		loBand := int(math.Pow(10, float64(L)-1.0))
-		hiBand := int(math.Pow(10, float64(L))) - 1
-
-		bandRanges := intersectWithBand(ranges, loBand, hiBand)
-
-		if len(bandRanges) == 0 {
-			continue
-		}
-
-		divisors := mathutils.ProperDivisors(L)
-
-		for _, d := range divisors {
-			k := L / d
-
-			if atLeastTwice {
-				if k < 2 {
-					continue
-				}
-			} else {
-				if k != 2 {
-					continue
-				}
-			}
-
-			R := (int(math.Pow(10, float64(L))) - 1) / (int(math.Pow(10, float64(d))) - 1)
-			BDigitMin := int(math.Pow(10, float64(d)-1.0))
-			BDigitMax := int(math.Pow(10, float64(d))) - 1
-
-			for _, r := range bandRanges {
-				BMin := max(BDigitMin, (r.Lo+R-1)/R)
-				BMax := min(BDigitMax, r.Hi/R)
-
-				if BMin > BMax {
-					continue
-				}
-
-				for B := BMin; B <= BMax; B++ {
-					N := B * R
-					seen[N] = true
-				}
-			}
-		}
+	invalids, err := mathutils.Repeated(10, pattern, set)
+	if err != nil {
+		return 0, err
 	}
 
 	res := 0
-	for k := range seen {
-		res += k
+	for n := range invalids {
+		res += n
 	}
 
-	return res
+	return res, nil
 }
@@ -0,0 +1,195 @@
+package automaton
+
+import (
+	"hash/maphash"
+	"slices"
+)
+
+type Rule[S comparable] func(cell S, neighbours []S) S
+
+type Mode int
+
+const (
+	Synchronous Mode = iota
+	Asynchronous
+)
+
+type Stats[S comparable] struct {
+	Generation int
+	Changed    int
+	Population map[S]int
+}
+
+type Result[S comparable] struct {
+	Generations []Stats[S]
+	Fixpoint    bool
+	Cycle       bool
+	CycleStart  int
+	CycleLength int
+}
+
+func (r Result[S]) TotalChanged() int {
+	total := 0
+	for _, s := range r.Generations {
+		total += s.Changed
+	}
+	return total
+}
+
+type Automaton[S comparable] struct {
+	grid          *Grid[S]
+	scratch       *Grid[S]
+	neighbourhood Neighbourhood
+	rule          Rule[S]
+	mode          Mode
+	generation    int
+	digest        func(cells []S) uint64
+	neighbours    []S
+	observers     []func(*Grid[S], Stats[S])
+}
+
+func New[S comparable](grid *Grid[S], neighbourhood Neighbourhood, rule Rule[S], mode Mode) *Automaton[S] {
+	return &Automaton[S]{
+		grid:          grid,
+		scratch:       grid.Clone(),
+		neighbourhood: neighbourhood,
+		rule:          rule,
+		mode:          mode,
+		digest:        cellDigest[S](maphash.MakeSeed()),
+		neighbours:    make([]S, 0, len(neighbourhood)),
+	}
+}
+
+func (a *Automaton[S]) Grid() *Grid[S] {
+	return a.grid
+}
+
+func (a *Automaton[S]) Generation() int {
+	return a.generation
+}
+
+func (a *Automaton[S]) Observe(fn func(*Grid[S], Stats[S])) {
+	a.observers = append(a.observers, fn)
+}
+
+func (a *Automaton[S]) Step() Stats[S] {
+	changed := 0
+
+	switch a.mode {
+	case Asynchronous:
+		for i, cell := range a.grid.Cells {
+			next := a.apply(a.grid, i)
+			if next != cell {
+				a.grid.Cells[i] = next
+				changed++
+			}
+		}
+	default:
+		for i, cell := range a.grid.Cells {
+			next := a.apply(a.grid, i)
+			if next != cell {
+				changed++
+			}
+			a.scratch.Cells[i] = next
+		}
+		a.grid, a.scratch = a.scratch, a.grid
+	}
+
+	a.generation++
+
+	stats := Stats[S]{
+		Generation: a.generation,
+		Changed:    changed,
+		Population: a.population(),
+	}
+
+	for _, fn := range a.observers {
+		fn(a.grid, stats)
+	}
+
+	return stats
+}
+
+func (a *Automaton[S]) Run(maxGenerations int) Result[S] {
+	var res Result[S]
+
+	seen := map[uint64][]snapshot[S]{a.hash(): {a.snapshot()}}
+
+	for maxGenerations <= 0 || len(res.Generations) < maxGenerations {
+		stats := a.Step()
+		res.Generations = append(res.Generations, stats)
+
+		if stats.Changed == 0 {
+			res.Fixpoint = true
+			break
+		}
+
+		h := a.hash()
+		if start, ok := a.find(seen[h]); ok {
+			res.Cycle = true
+			res.CycleStart = start
+			res.CycleLength = a.generation - start
+			break
+		}
+		seen[h] = append(seen[h], a.snapshot())
+	}
+
+	return res
+}
+
+func (a *Automaton[S]) apply(g *Grid[S], i int) S {
+	r := i / g.Cols
+	c := i % g.Cols
+
+	a.neighbours = a.neighbours[:0]
+	for _, o := range a.neighbourhood {
+		nr := r + o.Row
+		nc := c + o.Col
+		if g.InBounds(nr, nc) {
+			a.neighbours = append(a.neighbours, g.Get(nr, nc))
+		}
+	}
+
+	return a.rule(g.Cells[i], a.neighbours)
+}
+
+func (a *Automaton[S]) population() map[S]int {
+	counts := make(map[S]int)
+	for _, cell := range a.grid.Cells {
+		counts[cell]++
+	}
+	return counts
+}
+
+type snapshot[S comparable] struct {
+	generation int
+	cells      []S
+}
+
+func (a *Automaton[S]) snapshot() snapshot[S] {
+	return snapshot[S]{generation: a.generation, cells: slices.Clone(a.grid.Cells)}
+}
+
+func (a *Automaton[S]) find(candidates []snapshot[S]) (int, bool) {
+	for _, c := range candidates {
+		if slices.Equal(c.cells, a.grid.Cells) {
+			return c.generation, true
+		}
+	}
+	return 0, false
+}
+
+func (a *Automaton[S]) hash() uint64 {
+	return a.digest(a.grid.Cells)
+}
+
+func cellDigest[S comparable](seed maphash.Seed) func(cells []S) uint64 {
+	return func(cells []S) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		for _, cell := range cells {
+			maphash.WriteComparable(&h, cell)
+		}
+		return h.Sum64()
+	}
+}
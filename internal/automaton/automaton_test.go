@@ -0,0 +1,267 @@
+package automaton
+
+import (
+	"strings"
+	"testing"
+)
+
+func parseGrid(rows []string, alive rune) *Grid[bool] {
+	g := NewGrid[bool](len(rows), len(rows[0]))
+	for i, row := range rows {
+		for j, r := range row {
+			g.Set(i, j, r == alive)
+		}
+	}
+	return g
+}
+
+func render(g *Grid[bool]) string {
+	var b strings.Builder
+	for i := range g.Rows {
+		for j := range g.Cols {
+			if g.Get(i, j) {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func life(cell bool, neighbours []bool) bool {
+	alive := 0
+	for _, n := range neighbours {
+		if n {
+			alive++
+		}
+	}
+	return alive == 3 || (cell && alive == 2)
+}
+
+func removeAccessible(cell bool, neighbours []bool) bool {
+	if !cell {
+		return false
+	}
+	adj := 0
+	for _, n := range neighbours {
+		if n {
+			adj++
+		}
+	}
+	return adj >= 4
+}
+
+var rolls = []string{
+	"..@@.@@@@.",
+	"@@@.@.@.@@",
+	"@@@@@.@.@@",
+	"@.@@@@..@.",
+	"@@.@@@@.@@",
+	".@@@@@@@.@",
+	".@.@.@.@@@",
+	"@.@@@.@@@@",
+	".@@@@@@@@.",
+	"@.@.@@@.@.",
+}
+
+func TestAutomaton_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		grid        []string
+		alive       rune
+		rule        Rule[bool]
+		mode        Mode
+		generations int
+		changed     int
+		fixpoint    bool
+		cycle       bool
+		cycleStart  int
+		cycleLength int
+	}{
+		{
+			name:        "Single Removal Pass",
+			grid:        rolls,
+			alive:       '@',
+			rule:        removeAccessible,
+			mode:        Synchronous,
+			generations: 1,
+			changed:     13,
+		},
+		{
+			name:     "Removal Until Fixpoint",
+			grid:     rolls,
+			alive:    '@',
+			rule:     removeAccessible,
+			mode:     Synchronous,
+			changed:  43,
+			fixpoint: true,
+		},
+		{
+			name:     "Asynchronous Removal Until Fixpoint",
+			grid:     rolls,
+			alive:    '@',
+			rule:     removeAccessible,
+			mode:     Asynchronous,
+			changed:  43,
+			fixpoint: true,
+		},
+		{
+			name:     "Still Life",
+			grid:     []string{"....", ".##.", ".##.", "...."},
+			alive:    '#',
+			rule:     life,
+			mode:     Synchronous,
+			changed:  0,
+			fixpoint: true,
+		},
+		{
+			name:        "Blinker",
+			grid:        []string{".....", "..#..", "..#..", "..#..", "....."},
+			alive:       '#',
+			rule:        life,
+			mode:        Synchronous,
+			changed:     8,
+			cycle:       true,
+			cycleStart:  0,
+			cycleLength: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(parseGrid(tt.grid, tt.alive), Moore, tt.rule, tt.mode)
+
+			res := a.Run(tt.generations)
+
+			if res.TotalChanged() != tt.changed {
+				t.Errorf("TotalChanged() = %v, want %v", res.TotalChanged(), tt.changed)
+			}
+			if res.Fixpoint != tt.fixpoint {
+				t.Errorf("Fixpoint = %v, want %v", res.Fixpoint, tt.fixpoint)
+			}
+			if res.Cycle != tt.cycle {
+				t.Errorf("Cycle = %v, want %v", res.Cycle, tt.cycle)
+			}
+			if res.CycleStart != tt.cycleStart || res.CycleLength != tt.cycleLength {
+				t.Errorf("Cycle = (%v, %v), want (%v, %v)", res.CycleStart, res.CycleLength, tt.cycleStart, tt.cycleLength)
+			}
+		})
+	}
+}
+
+func TestAutomaton_Run_HashCollision(t *testing.T) {
+	tests := []struct {
+		name        string
+		grid        []string
+		alive       rune
+		rule        Rule[bool]
+		changed     int
+		fixpoint    bool
+		cycle       bool
+		cycleLength int
+	}{
+		{
+			name:     "Removal Until Fixpoint",
+			grid:     rolls,
+			alive:    '@',
+			rule:     removeAccessible,
+			changed:  43,
+			fixpoint: true,
+		},
+		{
+			name:        "Blinker",
+			grid:        []string{".....", "..#..", "..#..", "..#..", "....."},
+			alive:       '#',
+			rule:        life,
+			changed:     8,
+			cycle:       true,
+			cycleLength: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(parseGrid(tt.grid, tt.alive), Moore, tt.rule, Synchronous)
+			a.digest = func([]bool) uint64 { return 0 }
+
+			res := a.Run(0)
+
+			if res.TotalChanged() != tt.changed {
+				t.Errorf("TotalChanged() = %v, want %v", res.TotalChanged(), tt.changed)
+			}
+			if res.Fixpoint != tt.fixpoint {
+				t.Errorf("Fixpoint = %v, want %v", res.Fixpoint, tt.fixpoint)
+			}
+			if res.Cycle != tt.cycle || res.CycleLength != tt.cycleLength {
+				t.Errorf("Cycle = (%v, %v), want (%v, %v)", res.Cycle, res.CycleLength, tt.cycle, tt.cycleLength)
+			}
+		})
+	}
+}
+
+func TestAutomaton_Step(t *testing.T) {
+	g := parseGrid([]string{".....", "..#..", "..#..", "..#..", "....."}, '#')
+	a := New(g, Moore, life, Synchronous)
+
+	stats := a.Step()
+
+	expected := ".....\n.....\n.###.\n.....\n.....\n"
+	if render(a.Grid()) != expected {
+		t.Errorf("Step() grid = %q, want %q", render(a.Grid()), expected)
+	}
+	if stats.Generation != 1 {
+		t.Errorf("Step() generation = %v, want %v", stats.Generation, 1)
+	}
+	if stats.Changed != 4 {
+		t.Errorf("Step() changed = %v, want %v", stats.Changed, 4)
+	}
+	if stats.Population[true] != 3 || stats.Population[false] != 22 {
+		t.Errorf("Step() population = %v, want %v", stats.Population, map[bool]int{true: 3, false: 22})
+	}
+}
+
+func TestNeighbourhood_VonNeumann(t *testing.T) {
+	g := parseGrid([]string{"###", "###", "###"}, '#')
+	var counts []int
+	a := New(g, VonNeumann, func(cell bool, neighbours []bool) bool {
+		counts = append(counts, len(neighbours))
+		return cell
+	}, Synchronous)
+
+	a.Step()
+
+	expected := []int{2, 3, 2, 3, 4, 3, 2, 3, 2}
+	for i := range expected {
+		if counts[i] != expected[i] {
+			t.Errorf("neighbours = %v, want %v", counts, expected)
+			break
+		}
+	}
+}
+
+func TestAutomaton_Observe(t *testing.T) {
+	g := parseGrid([]string{".....", "..#..", "..#..", "..#..", "....."}, '#')
+	a := New(g, Moore, life, Synchronous)
+
+	var frames []string
+	a.Observe(func(g *Grid[bool], s Stats[bool]) {
+		frames = append(frames, render(g))
+	})
+
+	a.Run(3)
+
+	expected := []string{
+		".....\n.....\n.###.\n.....\n.....\n",
+		".....\n..#..\n..#..\n..#..\n.....\n",
+	}
+	if len(frames) != len(expected) {
+		t.Fatalf("Observe() frames = %v, want %v", len(frames), len(expected))
+	}
+	for i := range expected {
+		if frames[i] != expected[i] {
+			t.Errorf("Observe() frame %d = %q, want %q", i, frames[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package automaton
+
+type Offset struct {
+	Row int
+	Col int
+}
+
+type Neighbourhood []Offset
+
+var Moore = Neighbourhood{
+	{-1, -1}, {-1, 0}, {-1, 1},
+	{0, -1}, {0, 1},
+	{1, -1}, {1, 0}, {1, 1},
+}
+
+var VonNeumann = Neighbourhood{
+	{-1, 0},
+	{0, -1}, {0, 1},
+	{1, 0},
+}
+
+type Grid[S comparable] struct {
+	Rows  int
+	Cols  int
+	Cells []S
+}
+
+func NewGrid[S comparable](rows, cols int) *Grid[S] {
+	return &Grid[S]{
+		Rows:  rows,
+		Cols:  cols,
+		Cells: make([]S, rows*cols),
+	}
+}
+
+func (g *Grid[S]) InBounds(r, c int) bool {
+	return r >= 0 && r < g.Rows && c >= 0 && c < g.Cols
+}
+
+func (g *Grid[S]) Get(r, c int) S {
+	return g.Cells[r*g.Cols+c]
+}
+
+func (g *Grid[S]) Set(r, c int, s S) {
+	g.Cells[r*g.Cols+c] = s
+}
+
+func (g *Grid[S]) Count(s S) int {
+	n := 0
+	for _, cell := range g.Cells {
+		if cell == s {
+			n++
+		}
+	}
+	return n
+}
+
+func (g *Grid[S]) Clone() *Grid[S] {
+	cells := make([]S, len(g.Cells))
+	copy(cells, g.Cells)
+	return &Grid[S]{
+		Rows:  g.Rows,
+		Cols:  g.Cols,
+		Cells: cells,
+	}
+}
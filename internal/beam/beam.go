@@ -0,0 +1,198 @@
+package beam
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Direction int
+
+const (
+	North Direction = iota
+	East
+	South
+	West
+)
+
+func (d Direction) delta() (int, int) {
+	switch d {
+	case North:
+		return -1, 0
+	case East:
+		return 0, 1
+	case South:
+		return 1, 0
+	default:
+		return 0, -1
+	}
+}
+
+func (d Direction) left() Direction {
+	return (d + 3) % 4
+}
+
+func (d Direction) right() Direction {
+	return (d + 1) % 4
+}
+
+func (d Direction) horizontal() bool {
+	return d == East || d == West
+}
+
+func (d Direction) String() string {
+	switch d {
+	case North:
+		return "north"
+	case East:
+		return "east"
+	case South:
+		return "south"
+	default:
+		return "west"
+	}
+}
+
+type Beam struct {
+	Row int
+	Col int
+	Dir Direction
+}
+
+func (b Beam) advance(dir Direction) Beam {
+	dr, dc := dir.delta()
+	return Beam{Row: b.Row + dr, Col: b.Col + dc, Dir: dir}
+}
+
+type Behaviour func(b Beam) []Beam
+
+func Pass(b Beam) []Beam {
+	return []Beam{b.advance(b.Dir)}
+}
+
+func Absorb(Beam) []Beam {
+	return nil
+}
+
+func MirrorSlash(b Beam) []Beam {
+	switch b.Dir {
+	case North:
+		return []Beam{b.advance(East)}
+	case East:
+		return []Beam{b.advance(North)}
+	case South:
+		return []Beam{b.advance(West)}
+	default:
+		return []Beam{b.advance(South)}
+	}
+}
+
+func MirrorBackslash(b Beam) []Beam {
+	switch b.Dir {
+	case North:
+		return []Beam{b.advance(West)}
+	case East:
+		return []Beam{b.advance(South)}
+	case South:
+		return []Beam{b.advance(East)}
+	default:
+		return []Beam{b.advance(North)}
+	}
+}
+
+func SplitVertical(b Beam) []Beam {
+	if !b.Dir.horizontal() {
+		return Pass(b)
+	}
+	return []Beam{b.advance(North), b.advance(South)}
+}
+
+func SplitHorizontal(b Beam) []Beam {
+	if b.Dir.horizontal() {
+		return Pass(b)
+	}
+	return []Beam{b.advance(West), b.advance(East)}
+}
+
+func SplitSideways(b Beam) []Beam {
+	l := b.advance(b.Dir.left())
+	r := b.advance(b.Dir.right())
+	return []Beam{l.advance(b.Dir), r.advance(b.Dir)}
+}
+
+var DefaultTiles = map[rune]Behaviour{
+	'.':  Pass,
+	'S':  Pass,
+	'#':  Absorb,
+	'/':  MirrorSlash,
+	'\\': MirrorBackslash,
+	'|':  SplitVertical,
+	'-':  SplitHorizontal,
+	'^':  SplitSideways,
+}
+
+type Grid struct {
+	rows  [][]rune
+	cols  int
+	tiles map[rune]Behaviour
+}
+
+func NewGrid(lines []string, tiles map[rune]Behaviour) (*Grid, error) {
+	g := &Grid{
+		rows:  make([][]rune, len(lines)),
+		tiles: tiles,
+	}
+
+	for i, line := range lines {
+		g.rows[i] = []rune(line)
+		g.cols = max(g.cols, len(g.rows[i]))
+		for j, r := range g.rows[i] {
+			if _, ok := tiles[r]; !ok {
+				return nil, fmt.Errorf("unknown tile %q at row %d, col %d", r, i, j)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+func Parse(s string) (*Grid, error) {
+	return NewGrid(strings.Split(strings.TrimRight(s, "\n"), "\n"), DefaultTiles)
+}
+
+func (g *Grid) Rows() int {
+	return len(g.rows)
+}
+
+func (g *Grid) Cols() int {
+	return g.cols
+}
+
+func (g *Grid) Start() (Beam, error) {
+	for i, row := range g.rows {
+		for j, r := range row {
+			if r == 'S' {
+				return Beam{Row: i, Col: j, Dir: South}, nil
+			}
+		}
+	}
+	return Beam{}, fmt.Errorf("start not found")
+}
+
+func (g *Grid) inBounds(b Beam) bool {
+	return b.Row >= 0 && b.Row < len(g.rows) && b.Col >= 0 && b.Col < len(g.rows[b.Row])
+}
+
+func (g *Grid) step(b Beam) []Beam {
+	next := g.tiles[g.rows[b.Row][b.Col]](b)
+	out := next[:0:0]
+	for _, n := range next {
+		if g.inBounds(n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func (g *Grid) emits(b Beam) int {
+	return len(g.tiles[g.rows[b.Row][b.Col]](b))
+}
@@ -0,0 +1,222 @@
+package beam
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+var manifold = strings.Join([]string{
+	".......S.......",
+	"...............",
+	".......^.......",
+	"...............",
+	"......^.^......",
+	"...............",
+	".....^.^.^.....",
+	"...............",
+	"....^.^...^....",
+	"...............",
+	"...^.^...^.^...",
+	"...............",
+	"..^...^.....^..",
+	"...............",
+	".^.^.^.^.^...^.",
+	"...............",
+}, "\n")
+
+var contraption = strings.Join([]string{
+	`.|...\....`,
+	`|.-.\.....`,
+	`.....|-...`,
+	`........|.`,
+	`..........`,
+	`.........\`,
+	`..../.\\..`,
+	`.-.-/..|..`,
+	`.|....-|.\`,
+	`..//.|....`,
+}, "\n")
+
+func TestGrid_Trace(t *testing.T) {
+	tests := []struct {
+		name      string
+		grid      string
+		start     *Beam
+		energised int
+		splits    int
+		cyclic    bool
+	}{
+		{
+			name:      "Manifold",
+			grid:      manifold,
+			energised: 74,
+			splits:    21,
+		},
+		{
+			name:      "Contraption",
+			grid:      contraption,
+			start:     &Beam{Row: 0, Col: 0, Dir: East},
+			energised: 46,
+			splits:    7,
+			cyclic:    true,
+		},
+		{
+			name:      "Wall",
+			grid:      "S\n.\n#\n.",
+			energised: 3,
+		},
+		{
+			name:      "Loop",
+			grid:      `S/\` + "\n" + `\-/`,
+			energised: 6,
+			splits:    1,
+			cyclic:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Parse(tt.grid)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			start, err := startOrDefault(g, tt.start)
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			res := g.Trace(start)
+			if res.Energised != tt.energised {
+				t.Errorf("Trace() energised = %v, want %v", res.Energised, tt.energised)
+			}
+			if res.Splits != tt.splits {
+				t.Errorf("Trace() splits = %v, want %v", res.Splits, tt.splits)
+			}
+			if res.Cyclic != tt.cyclic {
+				t.Errorf("Trace() cyclic = %v, want %v", res.Cyclic, tt.cyclic)
+			}
+		})
+	}
+}
+
+func TestGrid_Timelines(t *testing.T) {
+	tests := []struct {
+		name      string
+		grid      string
+		start     *Beam
+		timelines int64
+		cycle     bool
+	}{
+		{
+			name:      "Manifold",
+			grid:      manifold,
+			timelines: 40,
+		},
+		{
+			name:      "Mirrors",
+			grid:      "S.\n\\|\n.#",
+			timelines: 2,
+		},
+		{
+			name:      "Splitter At Edge",
+			grid:      "S..\n-..",
+			timelines: 2,
+		},
+		{
+			name:  "Loop",
+			grid:  `S/\` + "\n" + `\-/`,
+			start: &Beam{Row: 0, Col: 0, Dir: South},
+			cycle: true,
+		},
+		{
+			name:  "Contraption",
+			grid:  contraption,
+			start: &Beam{Row: 0, Col: 0, Dir: East},
+			cycle: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Parse(tt.grid)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			start, err := startOrDefault(g, tt.start)
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			count, err := g.Timelines(start)
+			if tt.cycle {
+				var cerr *CycleError
+				if !errors.As(err, &cerr) {
+					t.Errorf("Timelines() error = %v, want cycle error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Timelines() error = %v", err)
+			}
+			if count.Cmp(big.NewInt(tt.timelines)) != 0 {
+				t.Errorf("Timelines() = %v, want %v", count, tt.timelines)
+			}
+		})
+	}
+}
+
+func TestGrid_Timelines_Overflow(t *testing.T) {
+	const depth = 100
+
+	rows := []string{strings.Repeat(".", depth) + "S" + strings.Repeat(".", depth)}
+	for k := range depth {
+		row := []byte(strings.Repeat(".", 2*depth+1))
+		for c := depth - k; c <= depth+k; c += 2 {
+			row[c] = '^'
+		}
+		rows = append(rows, string(row))
+	}
+
+	g, err := NewGrid(rows, DefaultTiles)
+	if err != nil {
+		t.Fatalf("NewGrid() error = %v", err)
+	}
+
+	start, err := g.Start()
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	count, err := g.Timelines(start)
+	if err != nil {
+		t.Fatalf("Timelines() error = %v", err)
+	}
+
+	expected := new(big.Int).Lsh(big.NewInt(1), depth)
+	if count.Cmp(expected) != 0 {
+		t.Errorf("Timelines() = %v, want %v", count, expected)
+	}
+
+	res := g.Trace(start)
+	if res.Splits != depth*(depth+1)/2 {
+		t.Errorf("Trace() splits = %v, want %v", res.Splits, depth*(depth+1)/2)
+	}
+}
+
+func TestNewGrid_UnknownTile(t *testing.T) {
+	_, err := NewGrid([]string{"S?"}, DefaultTiles)
+	if err == nil || err.Error() != `unknown tile '?' at row 0, col 1` {
+		t.Errorf("NewGrid() error = %v, want unknown tile", err)
+	}
+}
+
+func startOrDefault(g *Grid, start *Beam) (Beam, error) {
+	if start != nil {
+		return *start, nil
+	}
+	return g.Start()
+}
@@ -0,0 +1,137 @@
+package beam
+
+import (
+	"fmt"
+	"math/big"
+)
+
+type Result struct {
+	Energised int
+	Splits    int
+	Cyclic    bool
+}
+
+type Position struct {
+	Row int
+	Col int
+}
+
+type CycleError struct {
+	Beam Beam
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("beam loops back to row %d, col %d heading %v", e.Beam.Row, e.Beam.Col, e.Beam.Dir)
+}
+
+func (g *Grid) Trace(start Beam) Result {
+	energised := make(map[Position]bool)
+	splitters := make(map[Position]bool)
+	var res Result
+
+	g.walk(start, func(b Beam) {
+		pos := Position{Row: b.Row, Col: b.Col}
+		energised[pos] = true
+		if g.emits(b) > 1 {
+			splitters[pos] = true
+		}
+	}, nil, func(Beam) bool {
+		res.Cyclic = true
+		return true
+	})
+
+	res.Energised = len(energised)
+	res.Splits = len(splitters)
+
+	return res
+}
+
+func (g *Grid) Timelines(start Beam) (*big.Int, error) {
+	counts := make(map[Beam]*big.Int)
+	var cycle *CycleError
+
+	g.walk(start, nil, func(b Beam) {
+		emitted := g.emits(b)
+		if emitted == 0 {
+			counts[b] = big.NewInt(1)
+			return
+		}
+
+		next := g.step(b)
+		total := big.NewInt(int64(emitted - len(next)))
+		for _, n := range next {
+			total.Add(total, counts[n])
+		}
+		counts[b] = total
+	}, func(b Beam) bool {
+		cycle = &CycleError{Beam: b}
+		return false
+	})
+
+	if cycle != nil {
+		return nil, cycle
+	}
+
+	return counts[start], nil
+}
+
+func (g *Grid) walk(start Beam, pre, post func(Beam), cycle func(Beam) bool) {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+
+	if !g.inBounds(start) {
+		return
+	}
+
+	state := make(map[Beam]int)
+
+	type frame struct {
+		beam     Beam
+		expanded bool
+	}
+
+	stack := []frame{{beam: start}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		b := f.beam
+
+		if f.expanded {
+			if post != nil {
+				post(b)
+			}
+			state[b] = done
+			continue
+		}
+
+		switch state[b] {
+		case done:
+			continue
+		case active:
+			if !cycle(b) {
+				return
+			}
+			continue
+		}
+
+		state[b] = active
+		if pre != nil {
+			pre(b)
+		}
+
+		stack = append(stack, frame{beam: b, expanded: true})
+		for _, n := range g.step(b) {
+			switch state[n] {
+			case active:
+				if !cycle(n) {
+					return
+				}
+			case unvisited:
+				stack = append(stack, frame{beam: n})
+			}
+		}
+	}
+}
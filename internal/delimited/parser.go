@@ -1,23 +1,125 @@
 package delimited
 
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
 func ParseSpaceDelimited(s string) []string {
+	return Split(s, " ")
+}
+
+func Split(s, seps string) []string {
+	vals := make([]string, 0)
+	for field := range Fields(s, seps) {
+		vals = append(vals, field)
+	}
+	return vals
+}
+
+func Fields(s, seps string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		start := -1
+		for i := 0; i < len(s); {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if isSep(r, seps) {
+				if start >= 0 {
+					if !yield(s[start:i]) {
+						return
+					}
+					start = -1
+				}
+			} else if start < 0 {
+				start = i
+			}
+			i += size
+		}
+		if start >= 0 {
+			yield(s[start:])
+		}
+	}
+}
+
+func SplitQuoted(s, seps string, quote rune) ([]string, error) {
 	vals := make([]string, 0)
 
-	h := 0
-	t := 0
+	var field strings.Builder
+	inField := false
+	quoted := false
+	quotedAt := 0
 
-	for _, r := range s {
-		if r == ' ' {
-			if h != t && s[h] != ' ' {
-				vals = append(vals, s[h:t])
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case quoted && r == quote:
+			if next, n := utf8.DecodeRuneInString(s[i+size:]); next == quote && n > 0 {
+				field.WriteRune(quote)
+				size += n
+			} else {
+				quoted = false
 			}
-			h = t + 1
+		case quoted:
+			field.WriteString(s[i : i+size])
+		case r == quote:
+			quoted = true
+			quotedAt = i
+			inField = true
+		case isSep(r, seps):
+			if inField {
+				vals = append(vals, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteString(s[i : i+size])
+			inField = true
 		}
-		t++
+		i += size
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote at position %d", quotedAt)
+	}
+	if inField {
+		vals = append(vals, field.String())
 	}
-	if h != t && s[h] != ' ' {
-		vals = append(vals, s[h:t])
+	return vals, nil
+}
+
+type Column struct {
+	Start int
+	End   int
+}
+
+func FixedColumns(starts []int, width, gutter int) []Column {
+	cols := make([]Column, len(starts))
+	for i, start := range starts {
+		end := width
+		if i+1 < len(starts) {
+			end = starts[i+1] - gutter
+		}
+		cols[i] = Column{Start: start, End: max(start, end)}
 	}
+	return cols
+}
 
+func SliceColumns(row string, cols []Column) []string {
+	vals := make([]string, len(cols))
+	for i, c := range cols {
+		switch {
+		case c.End <= len(row):
+			vals[i] = row[c.Start:c.End]
+		case c.Start >= len(row):
+			vals[i] = strings.Repeat(" ", c.End-c.Start)
+		default:
+			vals[i] = row[c.Start:] + strings.Repeat(" ", c.End-len(row))
+		}
+	}
 	return vals
 }
+
+func isSep(r rune, seps string) bool {
+	return strings.ContainsRune(seps, r)
+}
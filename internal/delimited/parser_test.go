@@ -2,6 +2,7 @@ package delimited
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -67,3 +68,194 @@ func TestParseSpaceDelimited(t *testing.T) {
 		})
 	}
 }
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		seps     string
+		expected []string
+	}{
+		{
+			name:     "tabs and spaces",
+			input:    "a\tb  \t c",
+			seps:     " \t",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "multibyte fields",
+			input:    "héllo wörld ✓",
+			seps:     " ",
+			expected: []string{"héllo", "wörld", "✓"},
+		},
+		{
+			name:     "multibyte separator",
+			input:    "a→b→→c",
+			seps:     "→",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "several separators",
+			input:    "1,2;3 4",
+			seps:     ",; ",
+			expected: []string{"1", "2", "3", "4"},
+		},
+		{
+			name:     "no separators",
+			input:    "a b",
+			seps:     "",
+			expected: []string{"a b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Split(tt.input, tt.seps)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Split(%q, %q) = %v, want %v", tt.input, tt.seps, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFields_Allocations(t *testing.T) {
+	s := strings.Repeat("alpha  beta\tgamma ", 100)
+	n := 0
+	allocs := testing.AllocsPerRun(100, func() {
+		for field := range Fields(s, " \t") {
+			n += len(field)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Fields() allocs = %v, want %v", allocs, 0)
+	}
+}
+
+func TestSplitQuoted(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "plain",
+			input:    "a b  c",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "quoted separator",
+			input:    `a "b c" d`,
+			expected: []string{"a", "b c", "d"},
+		},
+		{
+			name:     "escaped quote",
+			input:    `"say ""hi""" x`,
+			expected: []string{`say "hi"`, "x"},
+		},
+		{
+			name:     "empty quoted field",
+			input:    `a "" b`,
+			expected: []string{"a", "", "b"},
+		},
+		{
+			name:     "quote inside field",
+			input:    `ab"c d"e`,
+			expected: []string{"abc de"},
+		},
+		{
+			name:  "unterminated",
+			input: `a "b c`,
+			err:   "unterminated quote at position 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SplitQuoted(tt.input, " ", '"')
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("SplitQuoted(%q) error = %v, want %v", tt.input, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SplitQuoted(%q) error = %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("SplitQuoted(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSliceColumns(t *testing.T) {
+	rows := []string{
+		"123 328  51 64 ",
+		" 45 64  387 23 ",
+		"  6 98  215 314",
+		"*   +   *   +  ",
+	}
+	cols := FixedColumns([]int{0, 4, 8, 12}, 15, 1)
+
+	expected := [][]string{
+		{"123", "328", " 51", "64 "},
+		{" 45", "64 ", "387", "23 "},
+		{"  6", "98 ", "215", "314"},
+		{"*  ", "+  ", "*  ", "+  "},
+	}
+
+	for i, row := range rows {
+		result := SliceColumns(strings.TrimRight(row, " "), cols)
+		if !reflect.DeepEqual(result, expected[i]) {
+			t.Errorf("SliceColumns(%q) = %q, want %q", row, result, expected[i])
+		}
+	}
+
+	short := SliceColumns("12", cols)
+	if !reflect.DeepEqual(short, []string{"12 ", "   ", "   ", "   "}) {
+		t.Errorf("SliceColumns(%q) = %q, want padded columns", "12", short)
+	}
+}
+
+func FuzzFields(f *testing.F) {
+	f.Add("hello   world", " ")
+	f.Add("a\tb→c", "\t→")
+	f.Add("", ",")
+	f.Add("\xff,\xfe", ",")
+
+	f.Fuzz(func(t *testing.T, s, seps string) {
+		expected := strings.FieldsFunc(s, func(r rune) bool {
+			return strings.ContainsRune(seps, r)
+		})
+		result := Split(s, seps)
+		if len(expected) == 0 {
+			expected = []string{}
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Split(%q, %q) = %q, want %q", s, seps, result, expected)
+		}
+	})
+}
+
+func FuzzSplitQuoted(f *testing.F) {
+	f.Add("a", "b c", `d"e`)
+	f.Add("", " ", `""`)
+	f.Add("0", "0", "\xaa")
+
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		fields := []string{a, b, c}
+		quoted := make([]string, len(fields))
+		for i, field := range fields {
+			quoted[i] = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+		}
+
+		result, err := SplitQuoted(strings.Join(quoted, " "), " ", '"')
+		if err != nil {
+			t.Fatalf("SplitQuoted() error = %v", err)
+		}
+		if !reflect.DeepEqual(result, fields) {
+			t.Errorf("SplitQuoted() = %q, want %q", result, fields)
+		}
+	})
+}
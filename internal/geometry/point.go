@@ -0,0 +1,83 @@
+package geometry
+
+import "fmt"
+
+type Point struct {
+	X int
+	Y int
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+func (p Point) sub(o Point) Point {
+	return Point{X: p.X - o.X, Y: p.Y - o.Y}
+}
+
+func cross(a, b Point) int {
+	return a.X*b.Y - a.Y*b.X
+}
+
+type Orientation int
+
+const (
+	Collinear Orientation = iota
+	Clockwise
+	CounterClockwise
+)
+
+func (o Orientation) String() string {
+	switch o {
+	case Clockwise:
+		return "clockwise"
+	case CounterClockwise:
+		return "counter-clockwise"
+	default:
+		return "collinear"
+	}
+}
+
+func orientationOf(v int) Orientation {
+	switch {
+	case v > 0:
+		return CounterClockwise
+	case v < 0:
+		return Clockwise
+	default:
+		return Collinear
+	}
+}
+
+func Orient(a, b, c Point) Orientation {
+	return orientationOf(cross(b.sub(a), c.sub(a)))
+}
+
+type Rect struct {
+	Min Point
+	Max Point
+}
+
+func NewRect(a, b Point) Rect {
+	return Rect{
+		Min: Point{X: min(a.X, b.X), Y: min(a.Y, b.Y)},
+		Max: Point{X: max(a.X, b.X), Y: max(a.Y, b.Y)},
+	}
+}
+
+func (r Rect) Width() int {
+	return r.Max.X - r.Min.X
+}
+
+func (r Rect) Height() int {
+	return r.Max.Y - r.Min.Y
+}
+
+func (r Rect) Corners() [4]Point {
+	return [4]Point{
+		r.Min,
+		{X: r.Max.X, Y: r.Min.Y},
+		r.Max,
+		{X: r.Min.X, Y: r.Max.Y},
+	}
+}
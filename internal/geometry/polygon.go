@@ -0,0 +1,136 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+type Polygon struct {
+	vertices []Point
+}
+
+func NewPolygon(vertices []Point) (*Polygon, error) {
+	if len(vertices) < 3 {
+		return nil, fmt.Errorf("polygon needs at least 3 vertices, got %d", len(vertices))
+	}
+
+	return &Polygon{vertices: slices.Clone(vertices)}, nil
+}
+
+func (p *Polygon) IsRectilinear() bool {
+	rectilinear := true
+	p.ForEachEdge(func(a, b Point) {
+		if a.X != b.X && a.Y != b.Y {
+			rectilinear = false
+		}
+	})
+	return rectilinear
+}
+
+func (p *Polygon) Vertices() []Point {
+	return slices.Clone(p.vertices)
+}
+
+func (p *Polygon) Len() int {
+	return len(p.vertices)
+}
+
+func (p *Polygon) ForEachEdge(fn func(a, b Point)) {
+	for i, a := range p.vertices {
+		fn(a, p.vertices[(i+1)%len(p.vertices)])
+	}
+}
+
+func (p *Polygon) SignedDoubleArea() int {
+	sum := 0
+	p.ForEachEdge(func(a, b Point) {
+		sum += cross(a, b)
+	})
+	return sum
+}
+
+func (p *Polygon) DoubleArea() int {
+	area := p.SignedDoubleArea()
+	if area < 0 {
+		return -area
+	}
+	return area
+}
+
+func (p *Polygon) Area() float64 {
+	return float64(p.DoubleArea()) / 2
+}
+
+func (p *Polygon) Perimeter() float64 {
+	perimeter := 0.0
+	p.ForEachEdge(func(a, b Point) {
+		perimeter += math.Hypot(float64(b.X-a.X), float64(b.Y-a.Y))
+	})
+	return perimeter
+}
+
+func (p *Polygon) Orientation() Orientation {
+	return orientationOf(p.SignedDoubleArea())
+}
+
+func (p *Polygon) OnBoundary(pt Point) bool {
+	return p.onBoundaryScaled(pt, 1)
+}
+
+func (p *Polygon) Contains(pt Point) bool {
+	return p.containsScaled(pt, 1)
+}
+
+func (p *Polygon) ContainsRect(r Rect) bool {
+	if r.Width() == 0 || r.Height() == 0 {
+		return p.ContainsSegment(r.Min, r.Max)
+	}
+
+	crossed := false
+	p.ForEachEdge(func(a, b Point) {
+		if !crossed && r.interiorCrossedBy(a, b) {
+			crossed = true
+		}
+	})
+	if crossed {
+		return false
+	}
+
+	center := Point{X: r.Min.X + r.Max.X, Y: r.Min.Y + r.Max.Y}
+	return p.containsScaled(center, 2)
+}
+
+func (p *Polygon) onBoundaryScaled(pt Point, s int) bool {
+	found := false
+	p.ForEachEdge(func(a, b Point) {
+		if !found && onSegment(pt, scale(a, s), scale(b, s)) {
+			found = true
+		}
+	})
+	return found
+}
+
+func (p *Polygon) containsScaled(pt Point, s int) bool {
+	if p.onBoundaryScaled(pt, s) {
+		return true
+	}
+
+	inside := false
+	p.ForEachEdge(func(a, b Point) {
+		a = scale(a, s)
+		b = scale(b, s)
+		if (a.Y > pt.Y) == (b.Y > pt.Y) {
+			return
+		}
+		o := Orient(a, b, pt)
+		if (b.Y > a.Y && o == CounterClockwise) || (b.Y < a.Y && o == Clockwise) {
+			inside = !inside
+		}
+	})
+	return inside
+}
+
+func scale(p Point, s int) Point {
+	return Point{X: p.X * s, Y: p.Y * s}
+}
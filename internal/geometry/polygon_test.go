@@ -0,0 +1,317 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	square = []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+
+	uShape = []Point{
+		{0, 0}, {10, 0}, {10, 10}, {8, 10},
+		{8, 2}, {2, 2}, {2, 10}, {0, 10},
+	}
+
+	example = []Point{
+		{7, 1}, {11, 1}, {11, 7}, {9, 7},
+		{9, 5}, {2, 5}, {2, 3}, {7, 3},
+	}
+
+	// Thin spike with a large concave angle at (1, 9), after the Reddit
+	// counterexample to the original crossing-only check.
+	spike = []Point{
+		{0, 0}, {9, 0}, {9, 1}, {1, 1},
+		{1, 9}, {9, 9}, {9, 10}, {0, 10},
+	}
+
+	flat = []Point{{0, 0}, {5, 0}, {10, 0}}
+
+	diamond = []Point{{4, 0}, {8, 4}, {4, 8}, {0, 4}}
+
+	arrow = []Point{{0, 0}, {10, 5}, {0, 10}, {4, 5}}
+)
+
+func mustPolygon(t *testing.T, vertices []Point) *Polygon {
+	t.Helper()
+	p, err := NewPolygon(vertices)
+	if err != nil {
+		t.Fatalf("NewPolygon() error = %v", err)
+	}
+	return p
+}
+
+func TestNewPolygon(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices []Point
+		err      string
+	}{
+		{
+			name:     "Square",
+			vertices: square,
+		},
+		{
+			name:     "Too Few Vertices",
+			vertices: []Point{{0, 0}, {1, 0}},
+			err:      "polygon needs at least 3 vertices, got 2",
+		},
+		{
+			name:     "Triangle",
+			vertices: []Point{{0, 0}, {4, 0}, {0, 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPolygon(tt.vertices)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("NewPolygon() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("NewPolygon() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPolygon_Measures(t *testing.T) {
+	tests := []struct {
+		name        string
+		vertices    []Point
+		doubleArea  int
+		perimeter   float64
+		orientation Orientation
+	}{
+		{
+			name:        "Square",
+			vertices:    square,
+			doubleArea:  32,
+			perimeter:   16,
+			orientation: CounterClockwise,
+		},
+		{
+			name:        "U Shape",
+			vertices:    uShape,
+			doubleArea:  2 * (100 - 48),
+			perimeter:   56,
+			orientation: CounterClockwise,
+		},
+		{
+			name:        "Example",
+			vertices:    example,
+			doubleArea:  2 * 30,
+			perimeter:   30,
+			orientation: CounterClockwise,
+		},
+		{
+			name:        "Reversed Square",
+			vertices:    []Point{{0, 4}, {4, 4}, {4, 0}, {0, 0}},
+			doubleArea:  32,
+			perimeter:   16,
+			orientation: Clockwise,
+		},
+		{
+			name:        "Flat",
+			vertices:    flat,
+			doubleArea:  0,
+			perimeter:   20,
+			orientation: Collinear,
+		},
+		{
+			name:        "Diamond",
+			vertices:    diamond,
+			doubleArea:  64,
+			perimeter:   4 * math.Sqrt(32),
+			orientation: CounterClockwise,
+		},
+		{
+			name:        "Arrow",
+			vertices:    arrow,
+			doubleArea:  60,
+			perimeter:   2*math.Sqrt(125) + 2*math.Sqrt(41),
+			orientation: CounterClockwise,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustPolygon(t, tt.vertices)
+			if p.DoubleArea() != tt.doubleArea {
+				t.Errorf("DoubleArea() = %v, want %v", p.DoubleArea(), tt.doubleArea)
+			}
+			if p.Perimeter() != tt.perimeter {
+				t.Errorf("Perimeter() = %v, want %v", p.Perimeter(), tt.perimeter)
+			}
+			if p.Orientation() != tt.orientation {
+				t.Errorf("Orientation() = %v, want %v", p.Orientation(), tt.orientation)
+			}
+		})
+	}
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	tests := []struct {
+		name       string
+		vertices   []Point
+		point      Point
+		contains   bool
+		onBoundary bool
+	}{
+		{"Square Interior", square, Point{2, 2}, true, false},
+		{"Square Corner", square, Point{4, 4}, true, true},
+		{"Square Edge", square, Point{0, 3}, true, true},
+		{"Square Outside", square, Point{5, 2}, false, false},
+		{"Square Ray Through Vertex", square, Point{-1, 0}, false, false},
+		{"U Arm", uShape, Point{1, 9}, true, false},
+		{"U Notch", uShape, Point{5, 5}, false, false},
+		{"U Notch Floor", uShape, Point{5, 2}, true, true},
+		{"U Above Notch", uShape, Point{5, 10}, false, false},
+		{"U Ray Along Edge", uShape, Point{-3, 2}, false, false},
+		{"Spike Pocket", spike, Point{5, 5}, false, false},
+		{"Spike Reflex Vertex", spike, Point{1, 9}, true, true},
+		{"Flat On Segment", flat, Point{3, 0}, true, true},
+		{"Flat Off Segment", flat, Point{3, 1}, false, false},
+		{"Diamond Centre", diamond, Point{4, 4}, true, false},
+		{"Diamond Edge", diamond, Point{6, 2}, true, true},
+		{"Diamond Bounding Corner", diamond, Point{1, 1}, false, false},
+		{"Arrow Notch", arrow, Point{2, 5}, false, false},
+		{"Arrow Reflex Vertex", arrow, Point{4, 5}, true, true},
+		{"Arrow Barb", arrow, Point{2, 2}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustPolygon(t, tt.vertices)
+			if p.Contains(tt.point) != tt.contains {
+				t.Errorf("Contains(%v) = %v, want %v", tt.point, p.Contains(tt.point), tt.contains)
+			}
+			if p.OnBoundary(tt.point) != tt.onBoundary {
+				t.Errorf("OnBoundary(%v) = %v, want %v", tt.point, p.OnBoundary(tt.point), tt.onBoundary)
+			}
+		})
+	}
+}
+
+func TestPolygon_ContainsRect(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices []Point
+		a        Point
+		b        Point
+		expected bool
+	}{
+		{"Whole Square", square, Point{0, 0}, Point{4, 4}, true},
+		{"Overhanging Square", square, Point{1, 1}, Point{5, 3}, false},
+		{"U Base", uShape, Point{0, 0}, Point{10, 2}, true},
+		{"U Arm", uShape, Point{0, 0}, Point{2, 10}, true},
+		{"U Notch", uShape, Point{2, 2}, Point{8, 10}, false},
+		{"U Across Arm", uShape, Point{0, 0}, Point{8, 10}, false},
+		{"U Notch Floor", uShape, Point{0, 2}, Point{10, 2}, true},
+		{"U Across Opening", uShape, Point{2, 10}, Point{8, 10}, false},
+		{"U Outer Edge", uShape, Point{10, 0}, Point{10, 10}, true},
+		{"Spike Pocket", spike, Point{1, 1}, Point{9, 9}, false},
+		{"Spike Spine", spike, Point{0, 0}, Point{1, 10}, true},
+		{"Spike Corners Only", spike, Point{0, 1}, Point{9, 9}, false},
+		{"Example Best", example, Point{9, 5}, Point{2, 3}, true},
+		{"Example Outside", example, Point{2, 5}, Point{11, 1}, false},
+		{"Point", square, Point{4, 4}, Point{4, 4}, true},
+		{"Flat Segment", flat, Point{0, 0}, Point{10, 0}, true},
+		{"Diamond Inscribed", diamond, Point{2, 2}, Point{6, 6}, true},
+		{"Diamond Too Wide", diamond, Point{1, 2}, Point{6, 6}, false},
+		{"Diamond Axis", diamond, Point{0, 4}, Point{8, 4}, true},
+		{"Arrow Across Notch", arrow, Point{1, 4}, Point{5, 6}, false},
+		{"Arrow Barb Segment", arrow, Point{1, 1}, Point{1, 9}, false},
+		{"Arrow Tip", arrow, Point{6, 4}, Point{8, 5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustPolygon(t, tt.vertices)
+			r := NewRect(tt.a, tt.b)
+			if p.ContainsRect(r) != tt.expected {
+				t.Errorf("ContainsRect(%v) = %v, want %v", r, p.ContainsRect(r), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolygon_IsRectilinear(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices []Point
+		expected bool
+	}{
+		{"Square", square, true},
+		{"U Shape", uShape, true},
+		{"Diamond", diamond, false},
+		{"Arrow", arrow, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustPolygon(t, tt.vertices)
+			if p.IsRectilinear() != tt.expected {
+				t.Errorf("IsRectilinear() = %v, want %v", p.IsRectilinear(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolygon_ContainsSegment(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices []Point
+		a        Point
+		b        Point
+		expected bool
+	}{
+		{"Square Diagonal", square, Point{0, 0}, Point{4, 4}, true},
+		{"Square Leaving", square, Point{2, 2}, Point{6, 3}, false},
+		{"U Across Notch", uShape, Point{1, 9}, Point{9, 9}, false},
+		{"U Along Floor", uShape, Point{0, 2}, Point{10, 2}, true},
+		{"Diamond Edge", diamond, Point{4, 0}, Point{8, 4}, true},
+		{"Diamond Chord", diamond, Point{2, 2}, Point{6, 6}, true},
+		{"Diamond Outside Corner", diamond, Point{0, 4}, Point{4, 0}, true},
+		{"Diamond Grazing", diamond, Point{0, 0}, Point{8, 8}, false},
+		{"Arrow Through Notch", arrow, Point{1, 1}, Point{1, 9}, false},
+		{"Arrow Through Reflex Vertex", arrow, Point{4, 5}, Point{10, 5}, true},
+		{"Arrow Barb To Barb", arrow, Point{2, 2}, Point{2, 8}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustPolygon(t, tt.vertices)
+			if p.ContainsSegment(tt.a, tt.b) != tt.expected {
+				t.Errorf("ContainsSegment(%v, %v) = %v, want %v", tt.a, tt.b, p.ContainsSegment(tt.a, tt.b), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSegmentsIntersect(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b, c, d Point
+		expected   bool
+	}{
+		{"Crossing", Point{0, 0}, Point{4, 4}, Point{0, 4}, Point{4, 0}, true},
+		{"Touching Endpoint", Point{0, 0}, Point{4, 4}, Point{4, 4}, Point{8, 0}, true},
+		{"T Junction", Point{0, 0}, Point{4, 0}, Point{2, 0}, Point{2, 5}, true},
+		{"Collinear Overlap", Point{0, 0}, Point{4, 0}, Point{2, 0}, Point{6, 0}, true},
+		{"Collinear Disjoint", Point{0, 0}, Point{2, 0}, Point{3, 0}, Point{6, 0}, false},
+		{"Parallel", Point{0, 0}, Point{4, 0}, Point{0, 1}, Point{4, 1}, false},
+		{"Near Miss", Point{0, 0}, Point{4, 4}, Point{3, 0}, Point{5, 2}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if SegmentsIntersect(tt.a, tt.b, tt.c, tt.d) != tt.expected {
+				t.Errorf("SegmentsIntersect() = %v, want %v", !tt.expected, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package geometry
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Raster struct {
+	polygon *Polygon
+	xs      []int
+	ys      []int
+	xIndex  map[int]int
+	yIndex  map[int]int
+	outside []int32
+}
+
+func NewRaster(p *Polygon) (*Raster, error) {
+	if !p.IsRectilinear() {
+		return nil, fmt.Errorf("raster needs a rectilinear polygon")
+	}
+
+	xs, xIndex := compress(p.vertices, func(pt Point) int { return pt.X })
+	ys, yIndex := compress(p.vertices, func(pt Point) int { return pt.Y })
+
+	width := len(xs) - 1
+	height := len(ys) - 1
+
+	toggles := make([]bool, max(width, 0)*max(height, 0))
+	p.ForEachEdge(func(a, b Point) {
+		if a.X != b.X || a.Y == b.Y {
+			return
+		}
+		col := xIndex[a.X]
+		if col >= width {
+			return
+		}
+		lo, hi := yIndex[min(a.Y, b.Y)], yIndex[max(a.Y, b.Y)]
+		for row := lo; row < hi; row++ {
+			toggles[row*width+col] = !toggles[row*width+col]
+		}
+	})
+
+	stride := width + 1
+	outside := make([]int32, (height+1)*stride)
+	for row := range height {
+		inside := false
+		for col := range width {
+			inside = inside != toggles[row*width+col]
+			var v int32
+			if !inside {
+				v = 1
+			}
+			outside[(row+1)*stride+col+1] = v +
+				outside[row*stride+col+1] +
+				outside[(row+1)*stride+col] -
+				outside[row*stride+col]
+		}
+	}
+
+	return &Raster{
+		polygon: p,
+		xs:      xs,
+		ys:      ys,
+		xIndex:  xIndex,
+		yIndex:  yIndex,
+		outside: outside,
+	}, nil
+}
+
+func (r *Raster) ContainsRect(rect Rect) bool {
+	if rect.Width() == 0 || rect.Height() == 0 {
+		return r.polygon.ContainsRect(rect)
+	}
+
+	x0, ok0 := r.xIndex[rect.Min.X]
+	x1, ok1 := r.xIndex[rect.Max.X]
+	y0, ok2 := r.yIndex[rect.Min.Y]
+	y1, ok3 := r.yIndex[rect.Max.Y]
+	if !ok0 || !ok1 || !ok2 || !ok3 {
+		return r.polygon.ContainsRect(rect)
+	}
+
+	stride := len(r.xs)
+	count := r.outside[y1*stride+x1] -
+		r.outside[y0*stride+x1] -
+		r.outside[y1*stride+x0] +
+		r.outside[y0*stride+x0]
+
+	return count == 0
+}
+
+func compress(points []Point, coord func(Point) int) ([]int, map[int]int) {
+	values := make([]int, len(points))
+	for i, pt := range points {
+		values[i] = coord(pt)
+	}
+	slices.Sort(values)
+	values = slices.Compact(values)
+
+	index := make(map[int]int, len(values))
+	for i, v := range values {
+		index[v] = i
+	}
+
+	return values, index
+}
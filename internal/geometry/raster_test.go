@@ -0,0 +1,49 @@
+package geometry
+
+import (
+	"testing"
+)
+
+func TestRaster_ContainsRect(t *testing.T) {
+	shapes := []struct {
+		name     string
+		vertices []Point
+	}{
+		{"Square", square},
+		{"U Shape", uShape},
+		{"Example", example},
+		{"Spike", spike},
+		{"Flat", flat},
+	}
+
+	for _, shape := range shapes {
+		t.Run(shape.name, func(t *testing.T) {
+			p := mustPolygon(t, shape.vertices)
+			r, err := NewRaster(p)
+			if err != nil {
+				t.Fatalf("NewRaster() error = %v", err)
+			}
+
+			for _, a := range shape.vertices {
+				for _, b := range shape.vertices {
+					rect := NewRect(a, b)
+					if r.ContainsRect(rect) != p.ContainsRect(rect) {
+						t.Errorf("ContainsRect(%v) = %v, want %v", rect, r.ContainsRect(rect), p.ContainsRect(rect))
+					}
+				}
+			}
+
+			off := NewRect(Point{-1, -1}, Point{1, 1})
+			if r.ContainsRect(off) != p.ContainsRect(off) {
+				t.Errorf("ContainsRect(%v) = %v, want %v", off, r.ContainsRect(off), p.ContainsRect(off))
+			}
+		})
+	}
+}
+
+func TestNewRaster_NotRectilinear(t *testing.T) {
+	p := mustPolygon(t, diamond)
+	if _, err := NewRaster(p); err == nil {
+		t.Errorf("NewRaster() error = %v, want error", err)
+	}
+}
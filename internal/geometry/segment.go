@@ -0,0 +1,203 @@
+package geometry
+
+import (
+	"math/big"
+	"slices"
+)
+
+func SegmentsIntersect(a, b, c, d Point) bool {
+	o1 := Orient(a, b, c)
+	o2 := Orient(a, b, d)
+	o3 := Orient(c, d, a)
+	o4 := Orient(c, d, b)
+
+	if o1 != o2 && o3 != o4 && o1 != Collinear && o2 != Collinear && o3 != Collinear && o4 != Collinear {
+		return true
+	}
+
+	return onSegment(c, a, b) || onSegment(d, a, b) || onSegment(a, c, d) || onSegment(b, c, d)
+}
+
+func onSegment(p, a, b Point) bool {
+	if Orient(a, b, p) != Collinear {
+		return false
+	}
+	return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+		min(a.Y, b.Y) <= p.Y && p.Y <= max(a.Y, b.Y)
+}
+
+type fraction struct {
+	num int
+	den int
+}
+
+func newFraction(num, den int) fraction {
+	if den < 0 {
+		return fraction{num: -num, den: -den}
+	}
+	return fraction{num: num, den: den}
+}
+
+func (f fraction) less(o fraction) bool {
+	return f.num*o.den < o.num*f.den
+}
+
+func (r Rect) interiorCrossedBy(a, b Point) bool {
+	axes := [2][4]int{
+		{r.Min.X, r.Max.X, a.X, b.X - a.X},
+		{r.Min.Y, r.Max.Y, a.Y, b.Y - a.Y},
+	}
+
+	var lowers, uppers []fraction
+	for _, axis := range axes {
+		lo, hi, p, d := axis[0], axis[1], axis[2], axis[3]
+		if lo >= hi {
+			return false
+		}
+		if d == 0 {
+			if p <= lo || p >= hi {
+				return false
+			}
+			continue
+		}
+		t1 := newFraction(lo-p, d)
+		t2 := newFraction(hi-p, d)
+		if t2.less(t1) {
+			t1, t2 = t2, t1
+		}
+		lowers = append(lowers, t1)
+		uppers = append(uppers, t2)
+	}
+
+	zero := fraction{num: 0, den: 1}
+	one := fraction{num: 1, den: 1}
+	for _, l := range lowers {
+		if !l.less(one) {
+			return false
+		}
+		for _, u := range uppers {
+			if !l.less(u) {
+				return false
+			}
+		}
+	}
+	for _, u := range uppers {
+		if !zero.less(u) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *Polygon) ContainsSegment(a, b Point) bool {
+	if a == b {
+		return p.Contains(a)
+	}
+
+	ts := []*big.Rat{new(big.Rat), big.NewRat(1, 1)}
+	d1 := b.sub(a)
+	lenSq := d1.X*d1.X + d1.Y*d1.Y
+
+	add := func(num, den int) {
+		t := big.NewRat(int64(num), int64(den))
+		if t.Sign() >= 0 && t.Cmp(ts[1]) <= 0 {
+			ts = append(ts, t)
+		}
+	}
+
+	p.ForEachEdge(func(c, d Point) {
+		d2 := d.sub(c)
+		ac := c.sub(a)
+		denom := cross(d1, d2)
+		if denom != 0 {
+			t := newFraction(cross(ac, d2), denom)
+			u := newFraction(cross(ac, d1), denom)
+			if t.num >= 0 && t.num <= t.den && u.num >= 0 && u.num <= u.den {
+				add(t.num, t.den)
+			}
+			return
+		}
+		if cross(ac, d1) != 0 {
+			return
+		}
+		add(dot(ac, d1), lenSq)
+		add(dot(d.sub(a), d1), lenSq)
+	})
+
+	slices.SortFunc(ts, func(x, y *big.Rat) int { return x.Cmp(y) })
+	ts = slices.CompactFunc(ts, func(x, y *big.Rat) bool { return x.Cmp(y) == 0 })
+
+	half := big.NewRat(1, 2)
+	for i, t := range ts {
+		if !p.containsRat(a, d1, t) {
+			return false
+		}
+		if i > 0 {
+			mid := new(big.Rat).Add(ts[i-1], t)
+			mid.Mul(mid, half)
+			if !p.containsRat(a, d1, mid) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (p *Polygon) containsRat(a, dir Point, t *big.Rat) bool {
+	px := ratAlong(a.X, dir.X, t)
+	py := ratAlong(a.Y, dir.Y, t)
+
+	if px.IsInt() && py.IsInt() {
+		return p.Contains(Point{X: int(px.Num().Int64()), Y: int(py.Num().Int64())})
+	}
+
+	inside := false
+	onEdge := false
+	p.ForEachEdge(func(c, d Point) {
+		if onEdge {
+			return
+		}
+		o := orientRat(c, d, px, py)
+		if o == 0 && betweenRat(c.X, d.X, px) && betweenRat(c.Y, d.Y, py) {
+			onEdge = true
+			return
+		}
+		cy := big.NewRat(int64(c.Y), 1)
+		dy := big.NewRat(int64(d.Y), 1)
+		if (cy.Cmp(py) > 0) == (dy.Cmp(py) > 0) {
+			return
+		}
+		if (d.Y > c.Y && o > 0) || (d.Y < c.Y && o < 0) {
+			inside = !inside
+		}
+	})
+
+	return onEdge || inside
+}
+
+func ratAlong(origin, delta int, t *big.Rat) *big.Rat {
+	v := new(big.Rat).Mul(big.NewRat(int64(delta), 1), t)
+	return v.Add(v, big.NewRat(int64(origin), 1))
+}
+
+func orientRat(c, d Point, px, py *big.Rat) int {
+	dx := big.NewRat(int64(d.X-c.X), 1)
+	dy := big.NewRat(int64(d.Y-c.Y), 1)
+	ex := new(big.Rat).Sub(px, big.NewRat(int64(c.X), 1))
+	ey := new(big.Rat).Sub(py, big.NewRat(int64(c.Y), 1))
+	lhs := new(big.Rat).Mul(dx, ey)
+	rhs := new(big.Rat).Mul(dy, ex)
+	return lhs.Cmp(rhs)
+}
+
+func betweenRat(a, b int, v *big.Rat) bool {
+	lo := big.NewRat(int64(min(a, b)), 1)
+	hi := big.NewRat(int64(max(a, b)), 1)
+	return lo.Cmp(v) <= 0 && v.Cmp(hi) <= 0
+}
+
+func dot(a, b Point) int {
+	return a.X*b.X + a.Y*b.Y
+}
@@ -0,0 +1,151 @@
+package geometry
+
+import "slices"
+
+type DefectKind int
+
+const (
+	TooFewVertices DefectKind = iota
+	NotAxisAligned
+	RepeatedVertex
+	CollinearVertex
+	SelfIntersection
+)
+
+func (k DefectKind) String() string {
+	switch k {
+	case TooFewVertices:
+		return "too few vertices"
+	case NotAxisAligned:
+		return "edge not axis-aligned"
+	case RepeatedVertex:
+		return "repeated vertex"
+	case CollinearVertex:
+		return "collinear vertex"
+	default:
+		return "self-intersection"
+	}
+}
+
+type Defect struct {
+	Kind  DefectKind
+	Index int
+	Other int
+}
+
+func Validate(vertices []Point) []Defect {
+	return validate(vertices, false)
+}
+
+func ValidateRectilinear(vertices []Point) []Defect {
+	return validate(vertices, true)
+}
+
+func validate(vertices []Point, rectilinear bool) []Defect {
+	n := len(vertices)
+	if n < 3 {
+		return []Defect{{Kind: TooFewVertices, Index: -1, Other: -1}}
+	}
+
+	var defects []Defect
+
+	for i, a := range vertices {
+		j := (i + 1) % n
+		b := vertices[j]
+		if rectilinear && a.X != b.X && a.Y != b.Y {
+			defects = append(defects, Defect{Kind: NotAxisAligned, Index: i, Other: j})
+		}
+	}
+
+	first := make(map[Point]int, n)
+	for i, v := range vertices {
+		if j, ok := first[v]; ok {
+			defects = append(defects, Defect{Kind: RepeatedVertex, Index: i, Other: j})
+			continue
+		}
+		first[v] = i
+	}
+
+	for i, v := range vertices {
+		prev := vertices[(i+n-1)%n]
+		next := vertices[(i+1)%n]
+		if v == prev || v == next || Orient(prev, v, next) != Collinear {
+			continue
+		}
+		if dot(v.sub(prev), next.sub(v)) < 0 {
+			defects = append(defects, Defect{Kind: SelfIntersection, Index: (i + n - 1) % n, Other: i})
+			continue
+		}
+		defects = append(defects, Defect{Kind: CollinearVertex, Index: i, Other: -1})
+	}
+
+	var edges []int
+	for i := range n {
+		if vertices[i] != vertices[(i+1)%n] {
+			edges = append(edges, i)
+		}
+	}
+
+	for x, i := range edges {
+		a, b := vertices[i], vertices[(i+1)%n]
+		for y := x + 2; y < len(edges); y++ {
+			if x == 0 && y == len(edges)-1 {
+				continue
+			}
+			j := edges[y]
+			c, d := vertices[j], vertices[(j+1)%n]
+			if SegmentsIntersect(a, b, c, d) {
+				defects = append(defects, Defect{Kind: SelfIntersection, Index: i, Other: j})
+			}
+		}
+	}
+
+	return defects
+}
+
+func Normalize(vertices []Point) ([]Point, []int) {
+	indices := make([]int, len(vertices))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	for changed := true; changed && len(indices) >= 3; {
+		changed = false
+		n := len(indices)
+		kept := make([]int, 0, n)
+		for k, idx := range indices {
+			var prev Point
+			if len(kept) > 0 {
+				prev = vertices[kept[len(kept)-1]]
+			} else {
+				prev = vertices[indices[n-1]]
+			}
+			next := vertices[indices[(k+1)%n]]
+			v := vertices[idx]
+			if v == prev || (v != next && Orient(prev, v, next) == Collinear) {
+				changed = true
+				continue
+			}
+			kept = append(kept, idx)
+		}
+		indices = kept
+	}
+
+	points := make([]Point, len(indices))
+	for i, idx := range indices {
+		points[i] = vertices[idx]
+	}
+
+	if len(points) >= 3 {
+		signed := 0
+		for i, a := range points {
+			signed += cross(a, points[(i+1)%len(points)])
+		}
+		if signed < 0 {
+			slices.Reverse(points)
+			slices.Reverse(indices)
+		}
+	}
+
+	return points, indices
+}
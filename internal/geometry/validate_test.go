@@ -0,0 +1,135 @@
+package geometry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		vertices    []Point
+		rectilinear bool
+		expected    []Defect
+	}{
+		{
+			name:     "Valid",
+			vertices: uShape,
+			expected: nil,
+		},
+		{
+			name:     "Empty",
+			vertices: nil,
+			expected: []Defect{{Kind: TooFewVertices, Index: -1, Other: -1}},
+		},
+		{
+			name:     "Diagonal",
+			vertices: []Point{{0, 0}, {4, 0}, {4, 4}, {1, 3}},
+			expected: nil,
+		},
+		{
+			name:        "Diagonal Rectilinear",
+			vertices:    []Point{{0, 0}, {4, 0}, {4, 4}, {1, 3}},
+			rectilinear: true,
+			expected: []Defect{
+				{Kind: NotAxisAligned, Index: 2, Other: 3},
+				{Kind: NotAxisAligned, Index: 3, Other: 0},
+			},
+		},
+		{
+			name:     "Repeated",
+			vertices: []Point{{0, 0}, {4, 0}, {4, 4}, {4, 4}, {0, 4}},
+			expected: []Defect{{Kind: RepeatedVertex, Index: 3, Other: 2}},
+		},
+		{
+			name:     "Collinear",
+			vertices: []Point{{0, 0}, {2, 0}, {4, 0}, {4, 4}, {0, 4}},
+			expected: []Defect{{Kind: CollinearVertex, Index: 1, Other: -1}},
+		},
+		{
+			name:     "Doubles Back",
+			vertices: []Point{{0, 0}, {4, 0}, {2, 0}},
+			expected: []Defect{
+				{Kind: SelfIntersection, Index: 2, Other: 0},
+				{Kind: SelfIntersection, Index: 0, Other: 1},
+				{Kind: CollinearVertex, Index: 2, Other: -1},
+			},
+		},
+		{
+			name:     "Crossed Quadrilateral",
+			vertices: []Point{{0, 0}, {4, 4}, {4, 0}, {0, 4}},
+			expected: []Defect{{Kind: SelfIntersection, Index: 0, Other: 2}},
+		},
+		{
+			name: "Bowtie",
+			vertices: []Point{
+				{0, 0}, {4, 0}, {4, 2}, {2, 2},
+				{2, 4}, {6, 4}, {6, 2}, {0, 2},
+			},
+			expected: []Defect{
+				{Kind: SelfIntersection, Index: 1, Other: 6},
+				{Kind: SelfIntersection, Index: 2, Other: 6},
+				{Kind: SelfIntersection, Index: 3, Other: 6},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validate := Validate
+			if tt.rectilinear {
+				validate = ValidateRectilinear
+			}
+			defects := validate(tt.vertices)
+			if !reflect.DeepEqual(defects, tt.expected) {
+				t.Errorf("Validate() = %v, want %v", defects, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices []Point
+		points   []Point
+		indices  []int
+	}{
+		{
+			name:     "Already Normal",
+			vertices: square,
+			points:   square,
+			indices:  []int{0, 1, 2, 3},
+		},
+		{
+			name:     "Collinear And Repeated",
+			vertices: []Point{{0, 0}, {2, 0}, {4, 0}, {4, 4}, {4, 4}, {0, 4}, {0, 2}},
+			points:   []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+			indices:  []int{0, 2, 3, 5},
+		},
+		{
+			name:     "Clockwise",
+			vertices: []Point{{0, 4}, {4, 4}, {4, 0}, {0, 0}},
+			points:   []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+			indices:  []int{3, 2, 1, 0},
+		},
+		{
+			name:     "Flat",
+			vertices: flat,
+			points:   []Point{},
+			indices:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, indices := Normalize(tt.vertices)
+			if !reflect.DeepEqual(points, tt.points) {
+				t.Errorf("Normalize() points = %v, want %v", points, tt.points)
+			}
+			if !reflect.DeepEqual(indices, tt.indices) {
+				t.Errorf("Normalize() indices = %v, want %v", indices, tt.indices)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type CycleError[K comparable] struct {
+	Cycle []K
+}
+
+func (e *CycleError[K]) Error() string {
+	parts := make([]string, len(e.Cycle))
+	for i, k := range e.Cycle {
+		parts[i] = fmt.Sprint(k)
+	}
+	return fmt.Sprintf("cycle detected: %s", strings.Join(parts, " -> "))
+}
+
+const (
+	unvisited = iota
+	active
+	done
+)
+
+func (g *Digraph[K]) TopologicalSort() ([]K, error) {
+	state := make([]uint8, len(g.nodes))
+	order := make([]int, 0, len(g.nodes))
+
+	for u := range g.nodes {
+		cycle := g.walk(u, state, nil, func(v int) {
+			order = append(order, v)
+		})
+		if cycle != nil {
+			return nil, g.cycleError(cycle)
+		}
+	}
+
+	slices.Reverse(order)
+	return g.keys(order), nil
+}
+
+func (g *Digraph[K]) FindCycle() []K {
+	state := make([]uint8, len(g.nodes))
+	for u := range g.nodes {
+		if cycle := g.walk(u, state, nil, nil); cycle != nil {
+			return g.keys(cycle)
+		}
+	}
+	return nil
+}
+
+func (g *Digraph[K]) StronglyConnectedComponents() [][]K {
+	n := len(g.nodes)
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range index {
+		index[i] = -1
+	}
+
+	var components [][]K
+	var stack []int
+	next := 0
+
+	type frame struct {
+		v int
+		i int
+	}
+
+	for root := range g.nodes {
+		if index[root] >= 0 {
+			continue
+		}
+
+		call := []frame{{v: root}}
+		index[root], low[root] = next, next
+		next++
+		stack = append(stack, root)
+		onStack[root] = true
+
+		for len(call) > 0 {
+			f := &call[len(call)-1]
+			if f.i < len(g.out[f.v]) {
+				w := g.out[f.v][f.i]
+				f.i++
+				switch {
+				case index[w] < 0:
+					index[w], low[w] = next, next
+					next++
+					stack = append(stack, w)
+					onStack[w] = true
+					call = append(call, frame{v: w})
+				case onStack[w]:
+					low[f.v] = min(low[f.v], index[w])
+				}
+				continue
+			}
+
+			v := f.v
+			call = call[:len(call)-1]
+			if len(call) > 0 {
+				parent := call[len(call)-1].v
+				low[parent] = min(low[parent], low[v])
+			}
+
+			if low[v] != index[v] {
+				continue
+			}
+
+			var component []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			slices.Reverse(component)
+			components = append(components, g.keys(component))
+		}
+	}
+
+	return components
+}
+
+func (g *Digraph[K]) CountPaths(from, to K) (int, error) {
+	return CountPathsWith(g, Int, from, to)
+}
+
+func CountPathsWith[K comparable, V any](g *Digraph[K], c Counter[V], from, to K) (V, error) {
+	u, err := g.lookup(from)
+	if err != nil {
+		return c.Zero(), err
+	}
+	t, err := g.lookup(to)
+	if err != nil {
+		return c.Zero(), err
+	}
+
+	reaches := g.reaching(t)
+	counts := make([]V, len(g.nodes))
+	state := make([]uint8, len(g.nodes))
+
+	follow := func(v, w int) bool {
+		return v != t && reaches[w]
+	}
+
+	cycle := g.walk(u, state, follow, accumulate(g, c, t, reaches, counts))
+	if cycle != nil {
+		return c.Zero(), g.cycleError(cycle)
+	}
+
+	return counts[u], nil
+}
+
+func CountPathsToWith[K comparable, V any](g *Digraph[K], c Counter[V], to K) (map[K]V, error) {
+	t, err := g.lookup(to)
+	if err != nil {
+		return nil, err
+	}
+
+	reaches := g.reaching(t)
+	counts := make([]V, len(g.nodes))
+	state := make([]uint8, len(g.nodes))
+
+	follow := func(v, w int) bool {
+		return v != t && reaches[w]
+	}
+
+	post := accumulate(g, c, t, reaches, counts)
+
+	result := make(map[K]V)
+	for u := range g.nodes {
+		if !reaches[u] {
+			continue
+		}
+		if cycle := g.walk(u, state, follow, post); cycle != nil {
+			return nil, g.cycleError(cycle)
+		}
+		result[g.nodes[u]] = counts[u]
+	}
+	return result, nil
+}
+
+func CountPathsToFiniteWith[K comparable, V any](g *Digraph[K], c Counter[V], to K) (map[K]V, error) {
+	t, err := g.lookup(to)
+	if err != nil {
+		return nil, err
+	}
+
+	reaches := g.reaching(t)
+	pending := make([]int, len(g.nodes))
+	for v := range g.nodes {
+		if !reaches[v] || v == t {
+			continue
+		}
+		for _, w := range g.out[v] {
+			if reaches[w] {
+				pending[v]++
+			}
+		}
+	}
+
+	counts := make([]V, len(g.nodes))
+	post := accumulate(g, c, t, reaches, counts)
+
+	result := make(map[K]V)
+	queue := []int{t}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		post(v)
+		result[g.nodes[v]] = counts[v]
+		for _, u := range g.in[v] {
+			if !reaches[u] || u == t {
+				continue
+			}
+			pending[u]--
+			if pending[u] == 0 {
+				queue = append(queue, u)
+			}
+		}
+	}
+	return result, nil
+}
+
+func accumulate[K comparable, V any](g *Digraph[K], c Counter[V], t int, reaches []bool, counts []V) func(int) {
+	return func(v int) {
+		if v == t {
+			counts[v] = c.One()
+			return
+		}
+		sum := c.Zero()
+		for _, w := range g.out[v] {
+			if reaches[w] {
+				sum = c.Add(sum, counts[w])
+			}
+		}
+		counts[v] = sum
+	}
+}
+
+func (g *Digraph[K]) reachable(u int) []bool {
+	reached := make([]bool, len(g.nodes))
+	reached[u] = true
+	queue := []int{u}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range g.out[v] {
+			if !reached[w] {
+				reached[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	return reached
+}
+
+func (g *Digraph[K]) reaching(t int) []bool {
+	reaches := make([]bool, len(g.nodes))
+	reaches[t] = true
+	queue := []int{t}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range g.in[v] {
+			if !reaches[w] {
+				reaches[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	return reaches
+}
+
+func (g *Digraph[K]) walk(start int, state []uint8, follow func(v, w int) bool, post func(int)) []int {
+	if state[start] != unvisited {
+		return nil
+	}
+
+	type frame struct {
+		v int
+		i int
+	}
+
+	stack := []frame{{v: start}}
+	state[start] = active
+
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		if f.i >= len(g.out[f.v]) {
+			state[f.v] = done
+			if post != nil {
+				post(f.v)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		w := g.out[f.v][f.i]
+		f.i++
+		if follow != nil && !follow(f.v, w) {
+			continue
+		}
+
+		switch state[w] {
+		case active:
+			j := len(stack) - 1
+			for stack[j].v != w {
+				j--
+			}
+			cycle := make([]int, 0, len(stack)-j+1)
+			for _, fr := range stack[j:] {
+				cycle = append(cycle, fr.v)
+			}
+			return append(cycle, w)
+		case unvisited:
+			state[w] = active
+			stack = append(stack, frame{v: w})
+		}
+	}
+
+	return nil
+}
+
+func (g *Digraph[K]) cycleError(cycle []int) error {
+	return &CycleError[K]{Cycle: g.keys(cycle)}
+}
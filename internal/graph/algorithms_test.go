@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDigraph_TopologicalSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		edges    [][2]string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "Diamond",
+			edges:    diamond,
+			expected: []string{"a", "c", "b", "d"},
+		},
+		{
+			name:     "Chain",
+			edges:    [][2]string{{"c", "d"}, {"b", "c"}, {"a", "b"}},
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:  "Loop",
+			edges: loop,
+			err:   "cycle detected: a -> b -> c -> a",
+		},
+		{
+			name:  "Self Loop",
+			edges: [][2]string{{"a", "b"}, {"b", "b"}},
+			err:   "cycle detected: b -> b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, tt.edges)
+			order, err := g.TopologicalSort()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("TopologicalSort() error = %v, want %v", err, tt.err)
+				}
+				var cycle *CycleError[string]
+				if !errors.As(err, &cycle) {
+					t.Errorf("TopologicalSort() error = %T, want *CycleError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TopologicalSort() error = %v", err)
+			}
+			if !reflect.DeepEqual(order, tt.expected) {
+				t.Errorf("TopologicalSort() = %v, want %v", order, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDigraph_FindCycle(t *testing.T) {
+	if cycle := build(t, diamond).FindCycle(); cycle != nil {
+		t.Errorf("FindCycle() = %v, want %v", cycle, nil)
+	}
+	expected := []string{"a", "b", "c", "a"}
+	if cycle := build(t, loop).FindCycle(); !reflect.DeepEqual(cycle, expected) {
+		t.Errorf("FindCycle() = %v, want %v", cycle, expected)
+	}
+}
+
+func TestDigraph_StronglyConnectedComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		edges    [][2]string
+		expected [][]string
+	}{
+		{
+			name:     "Diamond",
+			edges:    diamond,
+			expected: [][]string{{"d"}, {"b"}, {"c"}, {"a"}},
+		},
+		{
+			name:     "Loop",
+			edges:    loop,
+			expected: [][]string{{"d"}, {"a", "b", "c"}},
+		},
+		{
+			name: "Two Loops",
+			edges: [][2]string{
+				{"a", "b"}, {"b", "a"}, {"b", "c"},
+				{"c", "d"}, {"d", "e"}, {"e", "c"},
+			},
+			expected: [][]string{{"c", "d", "e"}, {"a", "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, tt.edges)
+			components := g.StronglyConnectedComponents()
+			if !reflect.DeepEqual(components, tt.expected) {
+				t.Errorf("StronglyConnectedComponents() = %v, want %v", components, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDigraph_CountPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		edges    [][2]string
+		from, to string
+		expected int
+		err      string
+	}{
+		{
+			name:     "Diamond",
+			edges:    diamond,
+			from:     "a",
+			to:       "d",
+			expected: 2,
+		},
+		{
+			name:     "Same Node",
+			edges:    diamond,
+			from:     "b",
+			to:       "b",
+			expected: 1,
+		},
+		{
+			name:     "Unreachable",
+			edges:    diamond,
+			from:     "d",
+			to:       "a",
+			expected: 0,
+		},
+		{
+			name:     "Cycle Off Path",
+			edges:    [][2]string{{"a", "b"}, {"a", "x"}, {"x", "y"}, {"y", "x"}},
+			from:     "a",
+			to:       "b",
+			expected: 1,
+		},
+		{
+			name:  "Cycle On Path",
+			edges: loop,
+			from:  "a",
+			to:    "d",
+			err:   "cycle detected: a -> b -> c -> a",
+		},
+		{
+			name:  "Unknown Node",
+			edges: diamond,
+			from:  "a",
+			to:    "z",
+			err:   "node z not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, tt.edges)
+			count, err := g.CountPaths(tt.from, tt.to)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("CountPaths() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CountPaths() error = %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("CountPaths() = %v, want %v", count, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountPathsToFiniteWith(t *testing.T) {
+	g := build(t, append([][2]string{{"x", "a"}, {"e", "d"}, {"x", "e"}}, loop...))
+
+	counts, err := CountPathsToFiniteWith(g, Int, "d")
+	if err != nil {
+		t.Fatalf("CountPathsToFiniteWith() error = %v", err)
+	}
+
+	expected := map[string]int{"d": 1, "e": 1}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("CountPathsToFiniteWith() = %v, want %v", counts, expected)
+	}
+
+	acyclic, err := CountPathsToFiniteWith(g, Int, "e")
+	if err != nil {
+		t.Fatalf("CountPathsToFiniteWith() error = %v", err)
+	}
+	if !reflect.DeepEqual(acyclic, map[string]int{"e": 1, "x": 1}) {
+		t.Errorf("CountPathsToFiniteWith() = %v, want %v", acyclic, map[string]int{"e": 1, "x": 1})
+	}
+
+	if _, err := CountPathsToFiniteWith(g, Int, "z"); err == nil || err.Error() != "node z not found" {
+		t.Errorf("CountPathsToFiniteWith() error = %v, want %v", err, "node z not found")
+	}
+}
@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+type Counter[V any] interface {
+	Zero() V
+	One() V
+	Add(a, b V) V
+	Mul(a, b V) V
+	IsZero(v V) bool
+}
+
+var (
+	Int    Counter[int]      = intCounter{}
+	BigInt Counter[*big.Int] = bigCounter{}
+)
+
+func Mod(m uint64) Counter[uint64] {
+	if m == 0 {
+		panic("graph: modulus must be positive")
+	}
+	return modCounter{m: m}
+}
+
+type intCounter struct{}
+
+func (intCounter) Zero() int         { return 0 }
+func (intCounter) One() int          { return 1 }
+func (intCounter) Add(a, b int) int  { return a + b }
+func (intCounter) Mul(a, b int) int  { return a * b }
+func (intCounter) IsZero(v int) bool { return v == 0 }
+
+type bigCounter struct{}
+
+func (bigCounter) Zero() *big.Int             { return new(big.Int) }
+func (bigCounter) One() *big.Int              { return big.NewInt(1) }
+func (bigCounter) Add(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) }
+func (bigCounter) Mul(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) }
+func (bigCounter) IsZero(v *big.Int) bool     { return v.Sign() == 0 }
+
+type modCounter struct {
+	m uint64
+}
+
+func (c modCounter) Zero() uint64 { return 0 }
+
+func (c modCounter) One() uint64 { return 1 % c.m }
+
+func (c modCounter) Add(a, b uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	return bits.Rem64(carry, sum, c.m)
+}
+
+func (c modCounter) Mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, c.m)
+}
+
+func (c modCounter) IsZero(v uint64) bool { return v == 0 }
@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func layeredDAG(t *testing.T, width, layers int) *Digraph[string] {
+	t.Helper()
+	g := New[string]()
+	prev := []string{"src"}
+	for l := range layers {
+		layer := make([]string, width)
+		for i := range layer {
+			layer[i] = fmt.Sprintf("n%d_%d", l, i)
+			for _, p := range prev {
+				if err := g.AddEdge(p, layer[i]); err != nil {
+					t.Fatalf("AddEdge() error = %v", err)
+				}
+			}
+		}
+		prev = layer
+	}
+	for _, p := range prev {
+		if err := g.AddEdge(p, "dst"); err != nil {
+			t.Fatalf("AddEdge() error = %v", err)
+		}
+	}
+	return g
+}
+
+func TestCountPathsWith_LayeredDAG(t *testing.T) {
+	g := layeredDAG(t, 3, 100)
+
+	want := new(big.Int).Exp(big.NewInt(3), big.NewInt(100), nil)
+	got, err := CountPathsWith(g, BigInt, "src", "dst")
+	if err != nil {
+		t.Fatalf("CountPathsWith() error = %v", err)
+	}
+	if got.Cmp(want) != 0 {
+		t.Errorf("CountPathsWith() = %v, want %v", got, want)
+	}
+
+	const m = 1_000_000_007
+	wantMod := new(big.Int).Exp(big.NewInt(3), big.NewInt(100), big.NewInt(m)).Uint64()
+	gotMod, err := CountPathsWith(g, Mod(m), "src", "dst")
+	if err != nil {
+		t.Fatalf("CountPathsWith() error = %v", err)
+	}
+	if gotMod != wantMod {
+		t.Errorf("CountPathsWith() = %v, want %v", gotMod, wantMod)
+	}
+
+	small := layeredDAG(t, 3, 10)
+	gotInt, err := CountPathsWith(small, Int, "src", "dst")
+	if err != nil {
+		t.Fatalf("CountPathsWith() error = %v", err)
+	}
+	if gotInt != 59049 {
+		t.Errorf("CountPathsWith() = %v, want %v", gotInt, 59049)
+	}
+}
+
+func TestCountPathsViaWith_LayeredDAG(t *testing.T) {
+	g := layeredDAG(t, 4, 80)
+
+	tests := []struct {
+		name     string
+		via      []string
+		ordered  bool
+		exponent int64
+	}{
+		{"No Waypoints", nil, false, 80},
+		{"One Waypoint", []string{"n40_2"}, false, 79},
+		{"Unordered", []string{"n70_0", "n10_3"}, false, 78},
+		{"Ordered", []string{"n10_3", "n70_0"}, true, 78},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := new(big.Int).Exp(big.NewInt(4), big.NewInt(tt.exponent), nil)
+			got, err := CountPathsViaWith(g, BigInt, "src", "dst", tt.via, tt.ordered)
+			if err != nil {
+				t.Fatalf("CountPathsViaWith() error = %v", err)
+			}
+			if got.Cmp(want) != 0 {
+				t.Errorf("CountPathsViaWith() = %v, want %v", got, want)
+			}
+
+			const m = 998_244_353
+			wantMod := new(big.Int).Mod(want, big.NewInt(m)).Uint64()
+			gotMod, err := CountPathsViaWith(g, Mod(m), "src", "dst", tt.via, tt.ordered)
+			if err != nil {
+				t.Fatalf("CountPathsViaWith() error = %v", err)
+			}
+			if gotMod != wantMod {
+				t.Errorf("CountPathsViaWith() = %v, want %v", gotMod, wantMod)
+			}
+		})
+	}
+}
+
+func TestMod(t *testing.T) {
+	const m = math.MaxUint64 - 58
+	c := Mod(m)
+
+	tests := []struct {
+		name     string
+		got      uint64
+		expected uint64
+	}{
+		{"One", Mod(1).One(), 0},
+		{"Add Wraps", c.Add(m-1, m-2), m - 3},
+		{"Mul Wide", c.Mul(m-1, m-1), 1},
+		{"Mul Small", c.Mul(6, 7), 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+)
+
+func (g *Digraph[K]) Ancestors(k K) ([]K, error) {
+	u, err := g.lookup(k)
+	if err != nil {
+		return nil, err
+	}
+	return g.collect(g.reaching(u), u), nil
+}
+
+func (g *Digraph[K]) Descendants(k K) ([]K, error) {
+	u, err := g.lookup(k)
+	if err != nil {
+		return nil, err
+	}
+	return g.collect(g.reachable(u), u), nil
+}
+
+func (g *Digraph[K]) OnPath(from, to K) ([]K, error) {
+	sub, err := g.Between(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return sub.Nodes(), nil
+}
+
+func (g *Digraph[K]) ImmediateDominators(root K) (map[K]K, error) {
+	r, err := g.lookup(root)
+	if err != nil {
+		return nil, err
+	}
+
+	idom := g.dominatorTree(r)
+	result := make(map[K]K)
+	for v, d := range idom {
+		if d >= 0 {
+			result[g.nodes[v]] = g.nodes[d]
+		}
+	}
+	return result, nil
+}
+
+func (g *Digraph[K]) Dominators(from, to K) ([]K, error) {
+	u, err := g.lookup(from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := g.lookup(to)
+	if err != nil {
+		return nil, err
+	}
+
+	idom := g.dominatorTree(u)
+	if idom[t] < 0 {
+		return nil, fmt.Errorf("no path from %v to %v", from, to)
+	}
+
+	chain := []int{t}
+	for v := t; v != u; v = idom[v] {
+		chain = append(chain, idom[v])
+	}
+	slices.Reverse(chain)
+	return g.keys(chain), nil
+}
+
+func (g *Digraph[K]) ArticulationPoints(from, to K) ([]K, error) {
+	chain, err := g.Dominators(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) <= 2 {
+		return nil, nil
+	}
+	return chain[1 : len(chain)-1], nil
+}
+
+func (g *Digraph[K]) collect(marked []bool, except int) []K {
+	var keys []K
+	for v, ok := range marked {
+		if ok && v != except {
+			keys = append(keys, g.nodes[v])
+		}
+	}
+	return keys
+}
+
+func (g *Digraph[K]) dominatorTree(root int) []int {
+	order := g.postorder(root)
+	number := make([]int, len(g.nodes))
+	for i, v := range order {
+		number[v] = i
+	}
+
+	idom := make([]int, len(g.nodes))
+	for i := range idom {
+		idom[i] = -1
+	}
+	idom[root] = root
+
+	intersect := func(a, b int) int {
+		for a != b {
+			for number[a] < number[b] {
+				a = idom[a]
+			}
+			for number[b] < number[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for i := len(order) - 1; i >= 0; i-- {
+			v := order[i]
+			if v == root {
+				continue
+			}
+			d := -1
+			for _, p := range g.in[v] {
+				if idom[p] < 0 {
+					continue
+				}
+				if d < 0 {
+					d = p
+				} else {
+					d = intersect(p, d)
+				}
+			}
+			if d != idom[v] {
+				idom[v] = d
+				changed = true
+			}
+		}
+	}
+
+	return idom
+}
+
+func (g *Digraph[K]) postorder(root int) []int {
+	type frame struct {
+		v int
+		i int
+	}
+
+	visited := make([]bool, len(g.nodes))
+	visited[root] = true
+	stack := []frame{{v: root}}
+	var order []int
+
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		if f.i < len(g.out[f.v]) {
+			w := g.out[f.v][f.i]
+			f.i++
+			if !visited[w] {
+				visited[w] = true
+				stack = append(stack, frame{v: w})
+			}
+			continue
+		}
+		order = append(order, f.v)
+		stack = stack[:len(stack)-1]
+	}
+
+	return order
+}
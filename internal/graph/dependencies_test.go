@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDigraph_AncestorsDescendants(t *testing.T) {
+	g := build(t, devices)
+
+	tests := []struct {
+		name     string
+		query    func(string) ([]string, error)
+		node     string
+		expected []string
+	}{
+		{"Ancestors Of Ccc", g.Ancestors, "ccc", []string{"svr", "aaa", "bbb", "fft", "tty"}},
+		{"Ancestors Of Root", g.Ancestors, "svr", nil},
+		{"Descendants Of Dac", g.Descendants, "dac", []string{"fff", "ggg", "hhh", "out"}},
+		{"Descendants Of Sink", g.Descendants, "out", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := tt.query(tt.node)
+			if err != nil {
+				t.Fatalf("query error = %v", err)
+			}
+			if !reflect.DeepEqual(nodes, tt.expected) {
+				t.Errorf("query(%v) = %v, want %v", tt.node, nodes, tt.expected)
+			}
+		})
+	}
+
+	if _, err := g.Ancestors("zzz"); err == nil {
+		t.Errorf("Ancestors() error = %v, want error", err)
+	}
+}
+
+func TestDigraph_Dominators(t *testing.T) {
+	tests := []struct {
+		name     string
+		edges    [][2]string
+		from, to string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "Devices",
+			edges:    devices,
+			from:     "svr",
+			to:       "out",
+			expected: []string{"svr", "ccc", "fff", "out"},
+		},
+		{
+			name:     "Diamond",
+			edges:    diamond,
+			from:     "a",
+			to:       "d",
+			expected: []string{"a", "d"},
+		},
+		{
+			name:     "Through A Loop",
+			edges:    [][2]string{{"s", "a"}, {"a", "b"}, {"b", "a"}, {"b", "t"}, {"s", "c"}, {"c", "b"}},
+			from:     "s",
+			to:       "t",
+			expected: []string{"s", "b", "t"},
+		},
+		{
+			name:  "Unreachable",
+			edges: diamond,
+			from:  "d",
+			to:    "a",
+			err:   "no path from d to a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, tt.edges)
+			nodes, err := g.Dominators(tt.from, tt.to)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("Dominators() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Dominators() error = %v", err)
+			}
+			if !reflect.DeepEqual(nodes, tt.expected) {
+				t.Errorf("Dominators() = %v, want %v", nodes, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDigraph_ImmediateDominators(t *testing.T) {
+	g := build(t, devices)
+	idom, err := g.ImmediateDominators("svr")
+	if err != nil {
+		t.Fatalf("ImmediateDominators() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"svr": "svr", "aaa": "svr", "bbb": "svr", "fft": "aaa", "tty": "bbb",
+		"ccc": "svr", "ddd": "ccc", "eee": "ccc", "hub": "ddd", "dac": "eee",
+		"fff": "ccc", "ggg": "fff", "hhh": "fff", "out": "fff",
+	}
+	if !reflect.DeepEqual(idom, expected) {
+		t.Errorf("ImmediateDominators() = %v, want %v", idom, expected)
+	}
+}
+
+func TestDigraph_ArticulationPoints(t *testing.T) {
+	g := build(t, devices)
+	g.AddNode("lonely")
+	if err := g.AddEdge("out", "zzz"); err != nil {
+		t.Fatalf("AddEdge() error = %v", err)
+	}
+
+	points, err := g.ArticulationPoints("svr", "out")
+	if err != nil {
+		t.Fatalf("ArticulationPoints() error = %v", err)
+	}
+	expected := []string{"ccc", "fff"}
+	if !reflect.DeepEqual(points, expected) {
+		t.Errorf("ArticulationPoints() = %v, want %v", points, expected)
+	}
+
+	onPath, err := g.OnPath("ccc", "fff")
+	if err != nil {
+		t.Fatalf("OnPath() error = %v", err)
+	}
+	expected = []string{"ccc", "ddd", "eee", "hub", "fff", "dac"}
+	if !reflect.DeepEqual(onPath, expected) {
+		t.Errorf("OnPath() = %v, want %v", onPath, expected)
+	}
+}
+
+func TestDigraph_ArticulationPoints_Cycles(t *testing.T) {
+	tests := []struct {
+		name         string
+		edges        [][2]string
+		articulation []string
+		dominators   []string
+	}{
+		{
+			name:         "Back Edge Around A Separator",
+			edges:        [][2]string{{"s", "p"}, {"p", "d"}, {"d", "q"}, {"q", "t"}, {"q", "p"}},
+			articulation: []string{"p", "d", "q"},
+			dominators:   []string{"s", "p", "d", "q", "t"},
+		},
+		{
+			name:         "Detour Through A Cycle",
+			edges:        [][2]string{{"s", "t"}, {"s", "v"}, {"v", "t"}, {"v", "c"}, {"c", "v"}},
+			articulation: nil,
+			dominators:   []string{"s", "t"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, tt.edges)
+			points, err := g.ArticulationPoints("s", "t")
+			if err != nil {
+				t.Fatalf("ArticulationPoints() error = %v", err)
+			}
+			if !reflect.DeepEqual(points, tt.articulation) {
+				t.Errorf("ArticulationPoints() = %v, want %v", points, tt.articulation)
+			}
+
+			dominators, err := g.Dominators("s", "t")
+			if err != nil {
+				t.Fatalf("Dominators() error = %v", err)
+			}
+			if !reflect.DeepEqual(dominators, tt.dominators) {
+				t.Errorf("Dominators() = %v, want %v", dominators, tt.dominators)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package graph
+
+import "fmt"
+
+type Attrs map[string]string
+
+type Digraph[K comparable] struct {
+	index     map[K]int
+	nodes     []K
+	out       [][]int
+	in        [][]int
+	nodeAttrs map[int]Attrs
+	edgeAttrs map[[2]int]Attrs
+}
+
+func New[K comparable]() *Digraph[K] {
+	return &Digraph[K]{
+		index:     make(map[K]int),
+		nodeAttrs: make(map[int]Attrs),
+		edgeAttrs: make(map[[2]int]Attrs),
+	}
+}
+
+func (g *Digraph[K]) AddNode(k K) bool {
+	if _, ok := g.index[k]; ok {
+		return false
+	}
+	g.add(k)
+	return true
+}
+
+func (g *Digraph[K]) AddEdge(from, to K) error {
+	u := g.add(from)
+	v := g.add(to)
+	if g.hasEdge(u, v) {
+		return fmt.Errorf("edge %v -> %v already exists", from, to)
+	}
+	g.out[u] = append(g.out[u], v)
+	g.in[v] = append(g.in[v], u)
+	return nil
+}
+
+func (g *Digraph[K]) HasNode(k K) bool {
+	_, ok := g.index[k]
+	return ok
+}
+
+func (g *Digraph[K]) HasEdge(from, to K) bool {
+	u, ok := g.index[from]
+	if !ok {
+		return false
+	}
+	v, ok := g.index[to]
+	if !ok {
+		return false
+	}
+	return g.hasEdge(u, v)
+}
+
+func (g *Digraph[K]) Len() int {
+	return len(g.nodes)
+}
+
+func (g *Digraph[K]) EdgeCount() int {
+	n := 0
+	for _, adj := range g.out {
+		n += len(adj)
+	}
+	return n
+}
+
+func (g *Digraph[K]) Nodes() []K {
+	nodes := make([]K, len(g.nodes))
+	copy(nodes, g.nodes)
+	return nodes
+}
+
+func (g *Digraph[K]) Successors(k K) []K {
+	u, ok := g.index[k]
+	if !ok {
+		return nil
+	}
+	return g.keys(g.out[u])
+}
+
+func (g *Digraph[K]) Predecessors(k K) []K {
+	v, ok := g.index[k]
+	if !ok {
+		return nil
+	}
+	return g.keys(g.in[v])
+}
+
+func (g *Digraph[K]) SetNodeAttr(k K, key, value string) {
+	u := g.add(k)
+	if g.nodeAttrs[u] == nil {
+		g.nodeAttrs[u] = make(Attrs)
+	}
+	g.nodeAttrs[u][key] = value
+}
+
+func (g *Digraph[K]) NodeAttrs(k K) Attrs {
+	u, ok := g.index[k]
+	if !ok {
+		return nil
+	}
+	return g.nodeAttrs[u]
+}
+
+func (g *Digraph[K]) SetEdgeAttr(from, to K, key, value string) error {
+	if !g.HasEdge(from, to) {
+		return fmt.Errorf("edge %v -> %v not found", from, to)
+	}
+	e := [2]int{g.index[from], g.index[to]}
+	if g.edgeAttrs[e] == nil {
+		g.edgeAttrs[e] = make(Attrs)
+	}
+	g.edgeAttrs[e][key] = value
+	return nil
+}
+
+func (g *Digraph[K]) EdgeAttrs(from, to K) Attrs {
+	if !g.HasEdge(from, to) {
+		return nil
+	}
+	return g.edgeAttrs[[2]int{g.index[from], g.index[to]}]
+}
+
+func (g *Digraph[K]) Reverse() *Digraph[K] {
+	r := New[K]()
+	for _, k := range g.nodes {
+		r.add(k)
+	}
+	for u, adj := range g.out {
+		for _, v := range adj {
+			r.out[v] = append(r.out[v], u)
+			r.in[u] = append(r.in[u], v)
+		}
+	}
+	for u, attrs := range g.nodeAttrs {
+		r.nodeAttrs[u] = clone(attrs)
+	}
+	for e, attrs := range g.edgeAttrs {
+		r.edgeAttrs[[2]int{e[1], e[0]}] = clone(attrs)
+	}
+	return r
+}
+
+func (g *Digraph[K]) Clone() *Digraph[K] {
+	return g.subgraph(func(int) bool { return true })
+}
+
+func (g *Digraph[K]) Between(from, to K) (*Digraph[K], error) {
+	u, err := g.lookup(from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := g.lookup(to)
+	if err != nil {
+		return nil, err
+	}
+
+	reached := g.reachable(u)
+	reaches := g.reaching(t)
+	return g.subgraph(func(v int) bool {
+		return reached[v] && reaches[v]
+	}), nil
+}
+
+func (g *Digraph[K]) subgraph(keep func(int) bool) *Digraph[K] {
+	s := New[K]()
+	for u, k := range g.nodes {
+		if !keep(u) {
+			continue
+		}
+		s.add(k)
+		if attrs, ok := g.nodeAttrs[u]; ok {
+			s.nodeAttrs[s.index[k]] = clone(attrs)
+		}
+	}
+	for u, adj := range g.out {
+		if !keep(u) {
+			continue
+		}
+		for _, v := range adj {
+			if !keep(v) {
+				continue
+			}
+			a, b := s.index[g.nodes[u]], s.index[g.nodes[v]]
+			s.out[a] = append(s.out[a], b)
+			s.in[b] = append(s.in[b], a)
+			if attrs, ok := g.edgeAttrs[[2]int{u, v}]; ok {
+				s.edgeAttrs[[2]int{a, b}] = clone(attrs)
+			}
+		}
+	}
+	return s
+}
+
+func (g *Digraph[K]) add(k K) int {
+	if u, ok := g.index[k]; ok {
+		return u
+	}
+	u := len(g.nodes)
+	g.index[k] = u
+	g.nodes = append(g.nodes, k)
+	g.out = append(g.out, nil)
+	g.in = append(g.in, nil)
+	return u
+}
+
+func (g *Digraph[K]) hasEdge(u, v int) bool {
+	for _, w := range g.out[u] {
+		if w == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Digraph[K]) keys(ids []int) []K {
+	keys := make([]K, len(ids))
+	for i, id := range ids {
+		keys[i] = g.nodes[id]
+	}
+	return keys
+}
+
+func (g *Digraph[K]) lookup(k K) (int, error) {
+	u, ok := g.index[k]
+	if !ok {
+		return 0, fmt.Errorf("node %v not found", k)
+	}
+	return u, nil
+}
+
+func clone(attrs Attrs) Attrs {
+	c := make(Attrs, len(attrs))
+	for k, v := range attrs {
+		c[k] = v
+	}
+	return c
+}
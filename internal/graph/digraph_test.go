@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func build(t *testing.T, edges [][2]string) *Digraph[string] {
+	t.Helper()
+	g := New[string]()
+	for _, e := range edges {
+		if err := g.AddEdge(e[0], e[1]); err != nil {
+			t.Fatalf("AddEdge(%v, %v) error = %v", e[0], e[1], err)
+		}
+	}
+	return g
+}
+
+var (
+	diamond = [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}}
+
+	loop = [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}}
+)
+
+func TestDigraph_AddEdge(t *testing.T) {
+	g := build(t, diamond)
+
+	if err := g.AddEdge("a", "b"); err == nil || err.Error() != "edge a -> b already exists" {
+		t.Errorf("AddEdge() error = %v, want %v", err, "edge a -> b already exists")
+	}
+	if g.AddNode("a") {
+		t.Errorf("AddNode() = %v, want %v", true, false)
+	}
+	if !g.AddNode("e") {
+		t.Errorf("AddNode() = %v, want %v", false, true)
+	}
+	if g.Len() != 5 {
+		t.Errorf("Len() = %v, want %v", g.Len(), 5)
+	}
+	if g.EdgeCount() != 4 {
+		t.Errorf("EdgeCount() = %v, want %v", g.EdgeCount(), 4)
+	}
+	if !g.HasEdge("b", "d") || g.HasEdge("d", "b") || g.HasEdge("x", "a") {
+		t.Errorf("HasEdge() reported the wrong edges")
+	}
+}
+
+func TestDigraph_Neighbours(t *testing.T) {
+	g := build(t, diamond)
+
+	tests := []struct {
+		name     string
+		got      []string
+		expected []string
+	}{
+		{"Nodes", g.Nodes(), []string{"a", "b", "c", "d"}},
+		{"Successors", g.Successors("a"), []string{"b", "c"}},
+		{"Predecessors", g.Predecessors("d"), []string{"b", "c"}},
+		{"Sink", g.Successors("d"), []string{}},
+		{"Unknown", g.Successors("x"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.expected) {
+				t.Errorf("%s() = %v, want %v", tt.name, tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDigraph_Attrs(t *testing.T) {
+	g := build(t, diamond)
+	g.SetNodeAttr("a", "label", "start")
+	if err := g.SetEdgeAttr("a", "b", "weight", "3"); err != nil {
+		t.Fatalf("SetEdgeAttr() error = %v", err)
+	}
+	if err := g.SetEdgeAttr("b", "a", "weight", "3"); err == nil {
+		t.Errorf("SetEdgeAttr() error = %v, want error", err)
+	}
+
+	if got := g.NodeAttrs("a")["label"]; got != "start" {
+		t.Errorf("NodeAttrs() = %v, want %v", got, "start")
+	}
+	if got := g.EdgeAttrs("a", "b")["weight"]; got != "3" {
+		t.Errorf("EdgeAttrs() = %v, want %v", got, "3")
+	}
+	if g.NodeAttrs("b") != nil {
+		t.Errorf("NodeAttrs() = %v, want %v", g.NodeAttrs("b"), nil)
+	}
+}
+
+func TestDigraph_Reverse(t *testing.T) {
+	g := build(t, diamond)
+	if err := g.SetEdgeAttr("a", "b", "weight", "3"); err != nil {
+		t.Fatalf("SetEdgeAttr() error = %v", err)
+	}
+
+	r := g.Reverse()
+
+	if !reflect.DeepEqual(r.Successors("d"), []string{"b", "c"}) {
+		t.Errorf("Successors() = %v, want %v", r.Successors("d"), []string{"b", "c"})
+	}
+	if !reflect.DeepEqual(r.Predecessors("a"), []string{"b", "c"}) {
+		t.Errorf("Predecessors() = %v, want %v", r.Predecessors("a"), []string{"b", "c"})
+	}
+	if got := r.EdgeAttrs("b", "a")["weight"]; got != "3" {
+		t.Errorf("EdgeAttrs() = %v, want %v", got, "3")
+	}
+	if g.HasEdge("b", "a") {
+		t.Errorf("Reverse() modified the original graph")
+	}
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func (g *Digraph[K]) WriteDOT(w io.Writer, name string) error {
+	if _, err := fmt.Fprintf(w, "digraph %s {\n", strconv.Quote(name)); err != nil {
+		return err
+	}
+
+	for u, k := range g.nodes {
+		if _, err := fmt.Fprintf(w, "\t%s%s;\n", dotID(k), dotAttrs(g.nodeAttrs[u])); err != nil {
+			return err
+		}
+	}
+
+	for u, adj := range g.out {
+		for _, v := range adj {
+			attrs := dotAttrs(g.edgeAttrs[[2]int{u, v}])
+			if _, err := fmt.Fprintf(w, "\t%s -> %s%s;\n", dotID(g.nodes[u]), dotID(g.nodes[v]), attrs); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func dotID(k any) string {
+	return strconv.Quote(fmt.Sprint(k))
+}
+
+func dotAttrs(attrs Attrs) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + strconv.Quote(attrs[k])
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDigraph_WriteDOT(t *testing.T) {
+	g := build(t, diamond)
+	g.SetNodeAttr("a", "shape", "box")
+	g.SetNodeAttr("a", "label", "start \"a\"")
+	if err := g.SetEdgeAttr("c", "d", "color", "red"); err != nil {
+		t.Fatalf("SetEdgeAttr() error = %v", err)
+	}
+
+	var buf strings.Builder
+	if err := g.WriteDOT(&buf, "devices"); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+
+	expected := `digraph "devices" {
+	"a" [label="start \"a\"", shape="box"];
+	"b";
+	"c";
+	"d";
+	"a" -> "b";
+	"a" -> "c";
+	"b" -> "d";
+	"c" -> "d" [color="red"];
+}
+`
+	if buf.String() != expected {
+		t.Errorf("WriteDOT() = %v, want %v", buf.String(), expected)
+	}
+}
+
+func TestDigraph_Between(t *testing.T) {
+	g := build(t, devices)
+	g.SetNodeAttr("fft", "color", "red")
+
+	sub, err := g.Between("aaa", "dac")
+	if err != nil {
+		t.Fatalf("Between() error = %v", err)
+	}
+
+	expected := []string{"aaa", "fft", "ccc", "eee", "dac"}
+	if !reflect.DeepEqual(sub.Nodes(), expected) {
+		t.Errorf("Between() nodes = %v, want %v", sub.Nodes(), expected)
+	}
+	if sub.EdgeCount() != 4 {
+		t.Errorf("Between() edges = %v, want %v", sub.EdgeCount(), 4)
+	}
+	if sub.NodeAttrs("fft")["color"] != "red" {
+		t.Errorf("Between() attrs = %v, want %v", sub.NodeAttrs("fft"), Attrs{"color": "red"})
+	}
+
+	if _, err := g.Between("aaa", "zzz"); err == nil {
+		t.Errorf("Between() error = %v, want error", err)
+	}
+}
+
+func TestCountPathsToWith(t *testing.T) {
+	g := build(t, devices)
+	g.AddNode("lonely")
+
+	counts, err := CountPathsToWith(g, BigInt, "out")
+	if err != nil {
+		t.Fatalf("CountPathsToWith() error = %v", err)
+	}
+
+	expected := map[string]int64{"svr": 8, "ccc": 4, "dac": 2, "out": 1}
+	for k, want := range expected {
+		if counts[k] == nil || counts[k].Cmp(big.NewInt(want)) != 0 {
+			t.Errorf("CountPathsToWith()[%s] = %v, want %v", k, counts[k], want)
+		}
+	}
+	if _, ok := counts["lonely"]; ok {
+		t.Errorf("CountPathsToWith() included unreachable node %v", "lonely")
+	}
+	if len(counts) != g.Len()-1 {
+		t.Errorf("CountPathsToWith() = %v entries, want %v", len(counts), g.Len()-1)
+	}
+
+	if _, err := CountPathsToWith(build(t, loop), Int, "d"); err == nil {
+		t.Errorf("CountPathsToWith() error = %v, want error", err)
+	}
+}
@@ -0,0 +1,216 @@
+package graph
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"math/rand"
+	"slices"
+)
+
+func (g *Digraph[K]) Paths(from, to K) iter.Seq[[]K] {
+	return func(yield func([]K) bool) {
+		u, err := g.lookup(from)
+		if err != nil {
+			return
+		}
+		t, err := g.lookup(to)
+		if err != nil {
+			return
+		}
+
+		reaches := g.reaching(t)
+		if !reaches[u] {
+			return
+		}
+
+		type frame struct {
+			v int
+			i int
+		}
+
+		onPath := make([]bool, len(g.nodes))
+		stack := []frame{{v: u}}
+		onPath[u] = true
+
+		for len(stack) > 0 {
+			f := &stack[len(stack)-1]
+
+			if f.v == t {
+				path := make([]K, len(stack))
+				for i, fr := range stack {
+					path[i] = g.nodes[fr.v]
+				}
+				if !yield(path) {
+					return
+				}
+			}
+
+			if f.v == t || f.i >= len(g.out[f.v]) {
+				onPath[f.v] = false
+				stack = stack[:len(stack)-1]
+				continue
+			}
+
+			w := g.out[f.v][f.i]
+			f.i++
+			if reaches[w] && !onPath[w] {
+				onPath[w] = true
+				stack = append(stack, frame{v: w})
+			}
+		}
+	}
+}
+
+func (g *Digraph[K]) ShortestPaths(from, to K, k int) ([][]K, error) {
+	u, err := g.lookup(from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := g.lookup(to)
+	if err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	bannedNodes := make([]bool, len(g.nodes))
+	bannedEdges := make(map[[2]int]bool)
+
+	first := g.shortest(u, t, bannedNodes, bannedEdges)
+	if first == nil {
+		return nil, nil
+	}
+
+	found := [][]int{first}
+	var candidates [][]int
+
+	for len(found) < k {
+		last := found[len(found)-1]
+
+		for i := 0; i < len(last)-1; i++ {
+			spur := last[i]
+			root := last[:i+1]
+
+			clear(bannedEdges)
+			for _, p := range found {
+				if len(p) > i+1 && slices.Equal(p[:i+1], root) {
+					bannedEdges[[2]int{p[i], p[i+1]}] = true
+				}
+			}
+			clear(bannedNodes)
+			for _, v := range root[:i] {
+				bannedNodes[v] = true
+			}
+
+			tail := g.shortest(spur, t, bannedNodes, bannedEdges)
+			if tail == nil {
+				continue
+			}
+
+			candidate := append(slices.Clone(root[:i]), tail...)
+			if !slices.ContainsFunc(candidates, func(c []int) bool { return slices.Equal(c, candidate) }) {
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		best := 0
+		for i, c := range candidates {
+			if len(c) < len(candidates[best]) {
+				best = i
+			}
+		}
+		found = append(found, candidates[best])
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+
+	paths := make([][]K, len(found))
+	for i, p := range found {
+		paths[i] = g.keys(p)
+	}
+	return paths, nil
+}
+
+func (g *Digraph[K]) shortest(u, t int, bannedNodes []bool, bannedEdges map[[2]int]bool) []int {
+	if bannedNodes[u] {
+		return nil
+	}
+
+	prev := make([]int, len(g.nodes))
+	for i := range prev {
+		prev[i] = -1
+	}
+	prev[u] = u
+
+	queue := []int{u}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		if v == t {
+			var path []int
+			for w := t; w != u; w = prev[w] {
+				path = append(path, w)
+			}
+			path = append(path, u)
+			slices.Reverse(path)
+			return path
+		}
+
+		for _, w := range g.out[v] {
+			if prev[w] >= 0 || bannedNodes[w] || bannedEdges[[2]int{v, w}] {
+				continue
+			}
+			prev[w] = v
+			queue = append(queue, w)
+		}
+	}
+
+	return nil
+}
+
+func (g *Digraph[K]) SamplePaths(from, to K, n int, rng *rand.Rand) ([][]K, error) {
+	sub, err := g.Between(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if !sub.HasNode(from) {
+		return nil, fmt.Errorf("no paths from %v to %v", from, to)
+	}
+
+	counts, err := CountPathsToWith(sub, BigInt, to)
+	if err != nil {
+		return nil, err
+	}
+
+	t := sub.index[to]
+	paths := make([][]K, 0, n)
+	r := new(big.Int)
+
+	for range n {
+		v := sub.index[from]
+		path := []K{from}
+
+		for v != t {
+			r.Rand(rng, counts[sub.nodes[v]])
+			for _, w := range sub.out[v] {
+				c := counts[sub.nodes[w]]
+				if r.Cmp(c) < 0 {
+					v = w
+					break
+				}
+				r.Sub(r, c)
+			}
+			path = append(path, sub.nodes[v])
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
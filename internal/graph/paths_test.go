@@ -0,0 +1,176 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func joinPaths(paths [][]string) []string {
+	joined := make([]string, len(paths))
+	for i, p := range paths {
+		joined[i] = strings.Join(p, ">")
+	}
+	return joined
+}
+
+func TestDigraph_Paths(t *testing.T) {
+	tests := []struct {
+		name     string
+		edges    [][2]string
+		from, to string
+		expected []string
+	}{
+		{
+			name:     "Diamond",
+			edges:    diamond,
+			from:     "a",
+			to:       "d",
+			expected: []string{"a>b>d", "a>c>d"},
+		},
+		{
+			name:     "Loop",
+			edges:    loop,
+			from:     "b",
+			to:       "d",
+			expected: []string{"b>c>d"},
+		},
+		{
+			name: "Stops At Target",
+			edges: [][2]string{
+				{"a", "b"}, {"b", "c"}, {"c", "b"}, {"a", "c"},
+			},
+			from:     "a",
+			to:       "b",
+			expected: []string{"a>b", "a>c>b"},
+		},
+		{
+			name:     "Unreachable",
+			edges:    diamond,
+			from:     "d",
+			to:       "a",
+			expected: nil,
+		},
+		{
+			name:     "Unknown",
+			edges:    diamond,
+			from:     "a",
+			to:       "z",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, tt.edges)
+			paths := joinPaths(slices.Collect(g.Paths(tt.from, tt.to)))
+			if len(paths) == 0 {
+				paths = nil
+			}
+			if !reflect.DeepEqual(paths, tt.expected) {
+				t.Errorf("Paths() = %v, want %v", paths, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDigraph_Paths_Lazy(t *testing.T) {
+	g := layeredDAG(t, 10, 60)
+
+	n := 0
+	for path := range g.Paths("src", "dst") {
+		if len(path) != 62 {
+			t.Fatalf("Paths() yielded %d nodes, want %d", len(path), 62)
+		}
+		n++
+		if n == 5 {
+			break
+		}
+	}
+	if n != 5 {
+		t.Errorf("Paths() yielded %v paths, want %v", n, 5)
+	}
+}
+
+func TestDigraph_ShortestPaths(t *testing.T) {
+	edges := [][2]string{
+		{"s", "a"}, {"a", "t"},
+		{"s", "b"}, {"b", "c"}, {"c", "t"},
+		{"a", "c"},
+		{"b", "d"}, {"d", "e"}, {"e", "t"},
+	}
+
+	tests := []struct {
+		name     string
+		k        int
+		expected []string
+	}{
+		{"None", 0, nil},
+		{"One", 1, []string{"s>a>t"}},
+		{"Three", 3, []string{"s>a>t", "s>b>c>t", "s>a>c>t"}},
+		{"All", 10, []string{"s>a>t", "s>b>c>t", "s>a>c>t", "s>b>d>e>t"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, edges)
+			paths, err := g.ShortestPaths("s", "t", tt.k)
+			if err != nil {
+				t.Fatalf("ShortestPaths() error = %v", err)
+			}
+			joined := joinPaths(paths)
+			if len(joined) == 0 {
+				joined = nil
+			}
+			if !reflect.DeepEqual(joined, tt.expected) {
+				t.Errorf("ShortestPaths() = %v, want %v", joined, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDigraph_SamplePaths(t *testing.T) {
+	g := build(t, devices)
+	all := joinPaths(slices.Collect(g.Paths("svr", "out")))
+
+	const samples = 8000
+	paths, err := g.SamplePaths("svr", "out", samples, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("SamplePaths() error = %v", err)
+	}
+
+	seen := make(map[string]int)
+	for _, p := range joinPaths(paths) {
+		if !slices.Contains(all, p) {
+			t.Fatalf("SamplePaths() produced %v, which is not a path", p)
+		}
+		seen[p]++
+	}
+
+	want := samples / len(all)
+	for _, p := range all {
+		if seen[p] < want*8/10 || seen[p] > want*12/10 {
+			t.Errorf("SamplePaths() drew %v %d times, want about %d", p, seen[p], want)
+		}
+	}
+
+	if _, err := g.SamplePaths("out", "svr", 1, rand.New(rand.NewSource(1))); err == nil {
+		t.Errorf("SamplePaths() error = %v, want error", err)
+	}
+	if _, err := build(t, loop).SamplePaths("a", "d", 1, rand.New(rand.NewSource(1))); err == nil {
+		t.Errorf("SamplePaths() error = %v, want error", err)
+	}
+
+	offPath := build(t, append([][2]string{{"svr", "x"}, {"x", "y"}, {"y", "x"}, {"z", "out"}, {"z", "z"}}, devices...))
+	sampled, err := offPath.SamplePaths("svr", "out", 50, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("SamplePaths() with cycles off the path error = %v", err)
+	}
+	for _, p := range joinPaths(sampled) {
+		if !slices.Contains(all, p) {
+			t.Errorf("SamplePaths() produced %v, which is not a path", p)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *Digraph[K]) CountSimplePaths(from, to K, limit int) (int, error) {
+	return g.CountSimplePathsVia(from, to, nil, false, limit)
+}
+
+func (g *Digraph[K]) CountSimplePathsVia(from, to K, via []K, ordered bool, limit int) (int, error) {
+	u, err := g.lookup(from)
+	if err != nil {
+		return 0, err
+	}
+	t, err := g.lookup(to)
+	if err != nil {
+		return 0, err
+	}
+
+	stop := make(map[int]int, len(via))
+	for i, k := range via {
+		w, err := g.lookup(k)
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := stop[w]; ok {
+			return 0, fmt.Errorf("waypoint %v repeated", k)
+		}
+		stop[w] = i
+	}
+
+	reaches := g.reaching(t)
+	onPath := make([]bool, len(g.nodes))
+	count := 0
+	explored := 0
+	exceeded := false
+
+	leaf := func() {
+		explored++
+		exceeded = limit > 0 && explored > limit
+	}
+
+	var search func(v, visited int)
+	search = func(v, visited int) {
+		if i, ok := stop[v]; ok {
+			if ordered && i != visited {
+				leaf()
+				return
+			}
+			visited++
+		}
+
+		if v == t {
+			if visited == len(via) {
+				count++
+			}
+			leaf()
+			return
+		}
+
+		onPath[v] = true
+		extended := false
+		for _, w := range g.out[v] {
+			if exceeded {
+				break
+			}
+			if reaches[w] && !onPath[w] {
+				extended = true
+				search(w, visited)
+			}
+		}
+		onPath[v] = false
+
+		if !extended {
+			leaf()
+		}
+	}
+
+	search(u, 0)
+
+	if exceeded {
+		return 0, fmt.Errorf("more than %d simple paths explored from %v to %v%s", limit, from, to, describeVia(via, ordered))
+	}
+	return count, nil
+}
+
+func describeVia[K comparable](via []K, ordered bool) string {
+	if len(via) == 0 {
+		return ""
+	}
+
+	names := make([]string, len(via))
+	for i, k := range via {
+		names[i] = fmt.Sprint(k)
+	}
+	s := " via " + strings.Join(names, ", ")
+	if ordered {
+		s += " in order"
+	}
+	return s
+}
@@ -0,0 +1,132 @@
+package graph
+
+import "testing"
+
+func TestDigraph_CountSimplePaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		edges    [][2]string
+		from, to string
+		via      []string
+		ordered  bool
+		limit    int
+		expected int
+		err      string
+	}{
+		{
+			name:     "Acyclic Matches CountPaths",
+			edges:    devices,
+			from:     "svr",
+			to:       "out",
+			expected: 8,
+		},
+		{
+			name:     "Loop",
+			edges:    loop,
+			from:     "a",
+			to:       "d",
+			expected: 1,
+		},
+		{
+			name: "Two Ways Round",
+			edges: [][2]string{
+				{"s", "a"}, {"s", "b"}, {"a", "b"}, {"b", "a"},
+				{"a", "t"}, {"b", "t"},
+			},
+			from:     "s",
+			to:       "t",
+			expected: 4,
+		},
+		{
+			name: "Two Ways Round Via",
+			edges: [][2]string{
+				{"s", "a"}, {"s", "b"}, {"a", "b"}, {"b", "a"},
+				{"a", "t"}, {"b", "t"},
+			},
+			from:     "s",
+			to:       "t",
+			via:      []string{"a", "b"},
+			expected: 2,
+		},
+		{
+			name: "Two Ways Round Ordered",
+			edges: [][2]string{
+				{"s", "a"}, {"s", "b"}, {"a", "b"}, {"b", "a"},
+				{"a", "t"}, {"b", "t"},
+			},
+			from:     "s",
+			to:       "t",
+			via:      []string{"a", "b"},
+			ordered:  true,
+			expected: 1,
+		},
+		{
+			name:     "Within Limit",
+			edges:    devices,
+			from:     "svr",
+			to:       "out",
+			limit:    8,
+			expected: 8,
+		},
+		{
+			name:  "Over Limit",
+			edges: devices,
+			from:  "svr",
+			to:    "out",
+			limit: 7,
+			err:   "more than 7 simple paths explored from svr to out",
+		},
+		{
+			name: "Failed Branches Count Toward Limit",
+			edges: [][2]string{
+				{"s", "a"}, {"s", "b"}, {"a", "b"}, {"b", "a"},
+				{"a", "t"}, {"b", "t"},
+			},
+			from:    "s",
+			to:      "t",
+			via:     []string{"b", "a"},
+			ordered: true,
+			limit:   2,
+			err:     "more than 2 simple paths explored from s to t via b, a in order",
+		},
+		{
+			name: "Failed Branches Within Limit",
+			edges: [][2]string{
+				{"s", "a"}, {"s", "b"}, {"a", "b"}, {"b", "a"},
+				{"a", "t"}, {"b", "t"},
+			},
+			from:     "s",
+			to:       "t",
+			via:      []string{"b", "a"},
+			ordered:  true,
+			limit:    3,
+			expected: 1,
+		},
+		{
+			name:  "Unknown Node",
+			edges: loop,
+			from:  "a",
+			to:    "z",
+			err:   "node z not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, tt.edges)
+			count, err := g.CountSimplePathsVia(tt.from, tt.to, tt.via, tt.ordered, tt.limit)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("CountSimplePathsVia() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CountSimplePathsVia() error = %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("CountSimplePathsVia() = %v, want %v", count, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package graph
+
+import "fmt"
+
+const maxWaypoints = 20
+
+func (g *Digraph[K]) CountPathsVia(from, to K, via []K, ordered bool) (int, error) {
+	return CountPathsViaWith(g, Int, from, to, via, ordered)
+}
+
+func CountPathsViaWith[K comparable, V any](g *Digraph[K], c Counter[V], from, to K, via []K, ordered bool) (V, error) {
+	seen := make(map[K]bool, len(via))
+	for _, k := range via {
+		if seen[k] {
+			return c.Zero(), fmt.Errorf("waypoint %v repeated", k)
+		}
+		seen[k] = true
+	}
+
+	if ordered {
+		route := append(append([]K{from}, via...), to)
+		total := c.One()
+		for i := 1; i < len(route); i++ {
+			count, err := CountPathsWith(g, c, route[i-1], route[i])
+			if err != nil {
+				return c.Zero(), err
+			}
+			if c.IsZero(count) {
+				return c.Zero(), nil
+			}
+			total = c.Mul(total, count)
+		}
+		return total, nil
+	}
+
+	if len(via) > maxWaypoints {
+		return c.Zero(), fmt.Errorf("too many unordered waypoints: %d > %d", len(via), maxWaypoints)
+	}
+	if len(via) == 0 {
+		return CountPathsWith(g, c, from, to)
+	}
+
+	k := len(via)
+	start := make([]V, k)
+	end := make([]V, k)
+	between := make([][]V, k)
+	for i, w := range via {
+		var err error
+		if start[i], err = CountPathsWith(g, c, from, w); err != nil {
+			return c.Zero(), err
+		}
+		if end[i], err = CountPathsWith(g, c, w, to); err != nil {
+			return c.Zero(), err
+		}
+		between[i] = make([]V, k)
+		for j, v := range via {
+			if i == j {
+				between[i][j] = c.Zero()
+				continue
+			}
+			if between[i][j], err = CountPathsWith(g, c, w, v); err != nil {
+				return c.Zero(), err
+			}
+		}
+	}
+
+	paths := make([][]V, 1<<k)
+	for mask := range paths {
+		paths[mask] = make([]V, k)
+		for i := range paths[mask] {
+			paths[mask][i] = c.Zero()
+		}
+	}
+	for i := range via {
+		paths[1<<i][i] = start[i]
+	}
+
+	for mask := 1; mask < len(paths); mask++ {
+		for i := range via {
+			if c.IsZero(paths[mask][i]) {
+				continue
+			}
+			for j := range via {
+				if mask&(1<<j) != 0 || c.IsZero(between[i][j]) {
+					continue
+				}
+				next := mask | 1<<j
+				paths[next][j] = c.Add(paths[next][j], c.Mul(paths[mask][i], between[i][j]))
+			}
+		}
+	}
+
+	total := c.Zero()
+	full := len(paths) - 1
+	for i := range via {
+		total = c.Add(total, c.Mul(paths[full][i], end[i]))
+	}
+	return total, nil
+}
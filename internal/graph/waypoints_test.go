@@ -0,0 +1,105 @@
+package graph
+
+import "testing"
+
+var devices = [][2]string{
+	{"svr", "aaa"}, {"svr", "bbb"},
+	{"aaa", "fft"},
+	{"fft", "ccc"},
+	{"bbb", "tty"},
+	{"tty", "ccc"},
+	{"ccc", "ddd"}, {"ccc", "eee"},
+	{"ddd", "hub"},
+	{"hub", "fff"},
+	{"eee", "dac"},
+	{"dac", "fff"},
+	{"fff", "ggg"}, {"fff", "hhh"},
+	{"ggg", "out"},
+	{"hhh", "out"},
+}
+
+func TestDigraph_CountPathsVia(t *testing.T) {
+	tests := []struct {
+		name     string
+		edges    [][2]string
+		via      []string
+		ordered  bool
+		expected int
+		err      string
+	}{
+		{
+			name:     "No Waypoints",
+			edges:    devices,
+			expected: 8,
+		},
+		{
+			name:     "Both Stops",
+			edges:    devices,
+			via:      []string{"dac", "fft"},
+			expected: 2,
+		},
+		{
+			name:     "Both Stops Ordered",
+			edges:    devices,
+			via:      []string{"fft", "dac"},
+			ordered:  true,
+			expected: 2,
+		},
+		{
+			name:     "Both Stops Wrong Order",
+			edges:    devices,
+			via:      []string{"dac", "fft"},
+			ordered:  true,
+			expected: 0,
+		},
+		{
+			name:     "Exclusive Stops",
+			edges:    devices,
+			via:      []string{"dac", "hub"},
+			expected: 0,
+		},
+		{
+			name:     "Three Stops",
+			edges:    devices,
+			via:      []string{"hhh", "tty", "hub"},
+			expected: 1,
+		},
+		{
+			name:  "Repeated Stop",
+			edges: devices,
+			via:   []string{"dac", "dac"},
+			err:   "waypoint dac repeated",
+		},
+		{
+			name:  "Unknown Stop",
+			edges: devices,
+			via:   []string{"zzz"},
+			err:   "node zzz not found",
+		},
+		{
+			name:  "Cycle Between Stops",
+			edges: [][2]string{{"svr", "aaa"}, {"aaa", "bbb"}, {"bbb", "aaa"}, {"bbb", "out"}},
+			via:   []string{"aaa", "bbb"},
+			err:   "cycle detected: aaa -> bbb -> aaa",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := build(t, tt.edges)
+			count, err := g.CountPathsVia("svr", "out", tt.via, tt.ordered)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("CountPathsVia() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CountPathsVia() error = %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("CountPathsVia() = %v, want %v", count, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package input
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const tagName = "aoc"
+
+type FieldError struct {
+	Field string
+	Text  string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s: cannot parse %q: %v", e.Field, e.Text, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+type segment struct {
+	literal string
+	field   string
+}
+
+var patterns sync.Map
+
+func Unmarshal(line string, v any) error {
+	rv, err := target(v)
+	if err != nil {
+		return err
+	}
+	pattern, ok := typePattern(rv.Type())
+	if !ok {
+		return fmt.Errorf("%s has no %s pattern tag", rv.Type(), tagName)
+	}
+	return unmarshalStruct(line, pattern, rv, "")
+}
+
+func UnmarshalPattern(line, pattern string, v any) error {
+	rv, err := target(v)
+	if err != nil {
+		return err
+	}
+	return unmarshalStruct(line, pattern, rv, "")
+}
+
+func target(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("unmarshal target must be a non-nil pointer to a struct, got %T", v)
+	}
+	return rv.Elem(), nil
+}
+
+func typePattern(t reflect.Type) (string, bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Name == "_" {
+			if pattern, ok := f.Tag.Lookup(tagName); ok {
+				return pattern, true
+			}
+		}
+	}
+	return "", false
+}
+
+func compile(pattern string) ([]segment, error) {
+	if segs, ok := patterns.Load(pattern); ok {
+		return segs.([]segment), nil
+	}
+
+	var segs []segment
+	var literal strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(pattern) && pattern[i+1] == c:
+			literal.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed field in pattern %q", pattern)
+			}
+			name := pattern[i+1 : i+end]
+			if name == "" {
+				return nil, fmt.Errorf("empty field in pattern %q", pattern)
+			}
+			if literal.Len() > 0 {
+				segs = append(segs, segment{literal: literal.String()})
+				literal.Reset()
+			} else if len(segs) > 0 && segs[len(segs)-1].field != "" {
+				return nil, fmt.Errorf("fields %s and %s must be separated by literal text in pattern %q", segs[len(segs)-1].field, name, pattern)
+			}
+			segs = append(segs, segment{field: name})
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("unmatched '}' in pattern %q", pattern)
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	if literal.Len() > 0 {
+		segs = append(segs, segment{literal: literal.String()})
+	}
+
+	patterns.Store(pattern, segs)
+	return segs, nil
+}
+
+func unmarshalStruct(text, pattern string, v reflect.Value, path string) error {
+	segs, err := compile(pattern)
+	if err != nil {
+		return err
+	}
+
+	fail := func(err error) error {
+		if path == "" {
+			return err
+		}
+		return &FieldError{Field: path, Text: text, Err: err}
+	}
+
+	pos := 0
+	for i, seg := range segs {
+		if seg.field == "" {
+			if !strings.HasPrefix(text[pos:], seg.literal) {
+				return fail(fmt.Errorf("expected %q at offset %d", seg.literal, pos))
+			}
+			pos += len(seg.literal)
+			continue
+		}
+
+		end := len(text)
+		if i+1 < len(segs) {
+			next := segs[i+1].literal
+			idx := strings.Index(text[pos:], next)
+			if idx < 0 {
+				return fail(fmt.Errorf("expected %q after field %s", next, seg.field))
+			}
+			end = pos + idx
+		}
+
+		sf, ok := v.Type().FieldByName(seg.field)
+		if !ok || !sf.IsExported() {
+			return fmt.Errorf("pattern %q refers to unknown field %s of %s", pattern, seg.field, v.Type())
+		}
+
+		if err := setValue(text[pos:end], v.FieldByIndex(sf.Index), sf.Tag.Get(tagName), join(path, seg.field)); err != nil {
+			return err
+		}
+		pos = end
+	}
+
+	if pos != len(text) {
+		return fail(fmt.Errorf("unexpected trailing text %q", text[pos:]))
+	}
+	return nil
+}
+
+func setValue(text string, v reflect.Value, tag, path string) error {
+	fail := func(err error) error {
+		return &FieldError{Field: path, Text: text, Err: err}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return fail(err)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return fail(err)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return fail(err)
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return fail(err)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		parts := split(text, tag)
+		s := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(part, s.Index(i), "", fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Struct:
+		pattern, ok := typePattern(v.Type())
+		if !ok {
+			return fail(fmt.Errorf("%s has no %s pattern tag", v.Type(), tagName))
+		}
+		return unmarshalStruct(text, pattern, v, path)
+	default:
+		return fail(fmt.Errorf("unsupported kind %s", v.Kind()))
+	}
+	return nil
+}
+
+func split(text, tag string) []string {
+	sep, ok := strings.CutPrefix(tag, "sep=")
+	if !ok || sep == "" {
+		return strings.Fields(text)
+	}
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, sep)
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,193 @@
+package input
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type point struct {
+	_ struct{} `aoc:"{X},{Y},{Z}"`
+	X float64
+	Y float64
+	Z float64
+}
+
+type button struct {
+	_      struct{} `aoc:"({Lights})"`
+	Lights []int    `aoc:"sep=,"`
+}
+
+type machine struct {
+	_       struct{} `aoc:"[{Diagram}] {Buttons} {{{Joltage}}}"`
+	Diagram string
+	Buttons []button
+	Joltage []uint16 `aoc:"sep=,"`
+}
+
+type rangeLine struct {
+	_    struct{} `aoc:"{Name}: {Lo}-{Hi} {Tags}"`
+	Name string
+	Lo   int
+	Hi   int8
+	Tags []string
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		target   any
+		expected any
+		err      string
+	}{
+		{
+			name:     "Floats",
+			line:     "162,817,-812.5",
+			target:   &point{},
+			expected: &point{X: 162, Y: 817, Z: -812.5},
+		},
+		{
+			name:   "Nested Groups",
+			line:   "[.##.] (3) (1,3) (2) {3,5,4,7}",
+			target: &machine{},
+			expected: &machine{
+				Diagram: ".##.",
+				Buttons: []button{{Lights: []int{3}}, {Lights: []int{1, 3}}, {Lights: []int{2}}},
+				Joltage: []uint16{3, 5, 4, 7},
+			},
+		},
+		{
+			name:     "Whitespace Slice",
+			line:     "abc: 3-7  red green ",
+			target:   &rangeLine{},
+			expected: &rangeLine{Name: "abc", Lo: 3, Hi: 7, Tags: []string{"red", "green"}},
+		},
+		{
+			name:     "Empty Slice",
+			line:     "abc: 3-7 ",
+			target:   &rangeLine{},
+			expected: &rangeLine{Name: "abc", Lo: 3, Hi: 7, Tags: []string{}},
+		},
+		{
+			name:   "Bad Float",
+			line:   "1,two,3",
+			target: &point{},
+			err:    `field Y: cannot parse "two": strconv.ParseFloat: parsing "two": invalid syntax`,
+		},
+		{
+			name:   "Bad Nested Int",
+			line:   "[#] (1,x) {1}",
+			target: &machine{},
+			err:    `field Buttons[0].Lights[1]: cannot parse "x": strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			name:   "Nested Literal Mismatch",
+			line:   "[#] (1) 2) {1}",
+			target: &machine{},
+			err:    `field Buttons[1]: cannot parse "2)": expected "(" at offset 0`,
+		},
+		{
+			name:   "Out Of Range",
+			line:   "abc: 1-300 x",
+			target: &rangeLine{},
+			err:    `field Hi: cannot parse "300": strconv.ParseInt: parsing "300": value out of range`,
+		},
+		{
+			name:   "Missing Literal",
+			line:   "1,2",
+			target: &point{},
+			err:    `expected "," after field Y`,
+		},
+		{
+			name:   "Trailing Text",
+			line:   "[#] (1) {1} extra",
+			target: &machine{},
+			err:    `unexpected trailing text " extra"`,
+		},
+		{
+			name:   "No Pattern",
+			line:   "1",
+			target: &struct{ X int }{},
+			err:    "struct { X int } has no aoc pattern tag",
+		},
+		{
+			name:   "Not A Pointer",
+			line:   "1,2,3",
+			target: point{},
+			err:    "unmarshal target must be a non-nil pointer to a struct, got input.point",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal(tt.line, tt.target)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("Unmarshal() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(tt.target, tt.expected) {
+				t.Errorf("Unmarshal() = %+v, want %+v", tt.target, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_FieldError(t *testing.T) {
+	var p point
+	err := Unmarshal("1,2,z", &p)
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Z" || fieldErr.Text != "z" {
+		t.Fatalf("Unmarshal() error = %#v, want FieldError for Z", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Unmarshal() error = %v, want wrapped %v", err, strconv.ErrSyntax)
+	}
+}
+
+func TestUnmarshalPattern(t *testing.T) {
+	type pair struct {
+		X int
+		Y int
+	}
+
+	tests := []struct {
+		name     string
+		line     string
+		pattern  string
+		expected pair
+		err      string
+	}{
+		{"Plain", "7,1", "{X},{Y}", pair{7, 1}, ""},
+		{"Escaped Braces", "{7}=1", "{{{X}}}={Y}", pair{7, 1}, ""},
+		{"Adjacent Fields", "71", "{X}{Y}", pair{}, `fields X and Y must be separated by literal text in pattern "{X}{Y}"`},
+		{"Unclosed Field", "7", "{X", pair{}, `unclosed field in pattern "{X"`},
+		{"Unknown Field", "7,1", "{X},{W}", pair{}, `pattern "{X},{W}" refers to unknown field W of input.pair`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got pair
+			err := UnmarshalPattern(tt.line, tt.pattern, &got)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("UnmarshalPattern() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalPattern() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("UnmarshalPattern() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
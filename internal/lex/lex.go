@@ -0,0 +1,203 @@
+package lex
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+const EOF rune = -1
+
+var (
+	ErrUnexpected = errors.New("unexpected character")
+	ErrEOF        = errors.New("unexpected end of input")
+)
+
+type Kind int
+
+const (
+	Other Kind = iota
+	Space
+	Newline
+	Digit
+	Letter
+	Punct
+)
+
+func KindOf(r rune) Kind {
+	switch {
+	case r == ' ' || r == '\t':
+		return Space
+	case r == '\n' || r == '\r':
+		return Newline
+	case unicode.IsDigit(r):
+		return Digit
+	case unicode.IsLetter(r):
+		return Letter
+	case unicode.IsPunct(r) || unicode.IsSymbol(r):
+		return Punct
+	default:
+		return Other
+	}
+}
+
+type Error struct {
+	Pos  int
+	Line int
+	Col  int
+	Rune rune
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if errors.Is(e.Err, ErrUnexpected) {
+		return fmt.Sprintf("line %d, column %d: %v %q", e.Line, e.Col, e.Err, e.Rune)
+	}
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Col, e.Err)
+}
+
+func (e *Error) Kind() Kind {
+	return KindOf(e.Rune)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type Scanner struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func New(src string) *Scanner {
+	return &Scanner{src: src, line: 1, col: 1}
+}
+
+func (s *Scanner) Pos() int {
+	return s.pos
+}
+
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+func (s *Scanner) Col() int {
+	return s.col
+}
+
+func (s *Scanner) Done() bool {
+	return s.pos >= len(s.src)
+}
+
+func (s *Scanner) Peek() rune {
+	if s.Done() {
+		return EOF
+	}
+	r, _ := utf8.DecodeRuneInString(s.src[s.pos:])
+	return r
+}
+
+func (s *Scanner) Next() rune {
+	if s.Done() {
+		return EOF
+	}
+	r, size := utf8.DecodeRuneInString(s.src[s.pos:])
+	s.pos += size
+	if r == '\n' {
+		s.line++
+		s.col = 1
+	} else {
+		s.col++
+	}
+	return r
+}
+
+func (s *Scanner) Accept(r rune) bool {
+	if s.Peek() != r || r == EOF {
+		return false
+	}
+	s.Next()
+	return true
+}
+
+func (s *Scanner) While(pred func(rune) bool) string {
+	start := s.pos
+	for !s.Done() && pred(s.Peek()) {
+		s.Next()
+	}
+	return s.src[start:s.pos]
+}
+
+func (s *Scanner) Expect(r rune) error {
+	if s.Accept(r) {
+		return nil
+	}
+	return s.Unexpected()
+}
+
+func (s *Scanner) ExpectEOF() error {
+	if s.Done() {
+		return nil
+	}
+	return s.Unexpected()
+}
+
+func (s *Scanner) Int() (int, error) {
+	line, col, pos := s.line, s.col, s.pos
+	s.Accept('-')
+	s.While(isDigit)
+
+	n, err := strconv.Atoi(s.src[pos:s.pos])
+	if err != nil {
+		return 0, &Error{Pos: pos, Line: line, Col: col, Rune: s.Peek(), Err: err}
+	}
+	return n, nil
+}
+
+func (s *Scanner) Ident() (string, error) {
+	ident := s.While(isIdent)
+	if ident == "" {
+		return "", s.Unexpected()
+	}
+	return ident, nil
+}
+
+func (s *Scanner) List(sep, open, close rune, item func() error) error {
+	if err := s.Expect(open); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		if s.Accept(close) {
+			return nil
+		}
+		if err := s.Expect(sep); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Scanner) Unexpected() error {
+	if s.Done() {
+		return s.Fail(ErrEOF)
+	}
+	return s.Fail(ErrUnexpected)
+}
+
+func (s *Scanner) Fail(err error) error {
+	return &Error{Pos: s.pos, Line: s.line, Col: s.col, Rune: s.Peek(), Err: err}
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isIdent(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
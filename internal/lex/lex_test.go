@@ -0,0 +1,165 @@
+package lex
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestScanner_Int(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected int
+		rest     string
+		err      string
+	}{
+		{"Positive", "42,", 42, ",", ""},
+		{"Negative", "-7)", -7, ")", ""},
+		{"Empty", ",3", 0, "", "line 1, column 1: strconv.Atoi: parsing \"\": invalid syntax"},
+		{"Lone Minus", "-", 0, "", "line 1, column 1: strconv.Atoi: parsing \"-\": invalid syntax"},
+		{"Overflow", "99999999999999999999", 0, "", "line 1, column 1: strconv.Atoi: parsing \"99999999999999999999\": value out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.src)
+			n, err := s.Int()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("Int() error = %v, want %v", err, tt.err)
+				}
+				var numErr *strconv.NumError
+				if !errors.As(err, &numErr) {
+					t.Errorf("Int() error = %T, want wrapped *strconv.NumError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Int() error = %v", err)
+			}
+			if n != tt.expected {
+				t.Errorf("Int() = %v, want %v", n, tt.expected)
+			}
+			if rest := s.While(func(rune) bool { return true }); rest != tt.rest {
+				t.Errorf("Int() left %q, want %q", rest, tt.rest)
+			}
+		})
+	}
+}
+
+func TestScanner_Ident(t *testing.T) {
+	s := New("aaa: b_2-c\n  ?")
+
+	tests := []struct {
+		name     string
+		scan     func() (string, error)
+		expected string
+		err      string
+	}{
+		{"First", s.Ident, "aaa", ""},
+		{"Colon", func() (string, error) { return "", s.Expect(':') }, "", ""},
+		{"Space", func() (string, error) { return "", s.Expect(':') }, "", "line 1, column 5: unexpected character ' '"},
+		{"Second", func() (string, error) { s.Next(); return s.Ident() }, "b_2-c", ""},
+		{"Newline", func() (string, error) { return s.While(func(r rune) bool { return r == '\n' || r == ' ' }), nil }, "\n  ", ""},
+		{"Bad Rune", s.Ident, "", "line 2, column 3: unexpected character '?'"},
+		{"End", func() (string, error) { s.Next(); return s.Ident() }, "", "line 2, column 4: unexpected end of input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.scan()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("scan error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("scan error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("scan = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanner_List(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		open, close rune
+		expected    []int
+		err         string
+		pos         int
+		unexp       bool
+	}{
+		{"Single", "(3)", '(', ')', []int{3}, "", 0, false},
+		{"Several", "{7,4,3,5}", '{', '}', []int{7, 4, 3, 5}, "", 0, false},
+		{"Wrong Open", "[1]", '(', ')', nil, "line 1, column 1: unexpected character '['", 0, true},
+		{"Missing Close", "(1,2", '(', ')', nil, "line 1, column 5: unexpected end of input", 4, false},
+		{"Bad Separator", "(1;2)", '(', ')', nil, "line 1, column 3: unexpected character ';'", 2, true},
+		{"Repeated Separator", "(1,,2)", '(', ')', nil, "line 1, column 4: strconv.Atoi: parsing \"\": invalid syntax", 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.src)
+
+			var items []int
+			err := s.List(',', tt.open, tt.close, func() error {
+				n, err := s.Int()
+				items = append(items, n)
+				return err
+			})
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("List() error = %v, want %v", err, tt.err)
+				}
+				var lexErr *Error
+				if !errors.As(err, &lexErr) || lexErr.Pos != tt.pos {
+					t.Errorf("List() error position = %v, want %v", lexErr, tt.pos)
+				}
+				if errors.Is(err, ErrUnexpected) != tt.unexp {
+					t.Errorf("List() unexpected = %v, want %v", errors.Is(err, ErrUnexpected), tt.unexp)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if !reflect.DeepEqual(items, tt.expected) {
+				t.Errorf("List() = %v, want %v", items, tt.expected)
+			}
+			if err := s.ExpectEOF(); err != nil {
+				t.Errorf("ExpectEOF() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		r        rune
+		expected Kind
+	}{
+		{' ', Space},
+		{'\t', Space},
+		{'\n', Newline},
+		{'7', Digit},
+		{'ñ', Letter},
+		{'#', Punct},
+		{'{', Punct},
+		{'+', Punct},
+		{EOF, Other},
+		{'\x00', Other},
+	}
+
+	for _, tt := range tests {
+		if result := KindOf(tt.r); result != tt.expected {
+			t.Errorf("KindOf(%q) = %v, want %v", tt.r, result, tt.expected)
+		}
+	}
+}
@@ -232,3 +232,37 @@ func CoefsConsistentWithMatrix(matrix [][]float64, coefs []float64) bool {
 	}
 	return valid
 }
+
+func Pow(base, exp int) (int, bool) {
+	if exp < 0 {
+		return 0, false
+	}
+
+	result := 1
+	for {
+		var ok bool
+		if exp&1 == 1 {
+			if result, ok = checkedMul(result, base); !ok {
+				return 0, false
+			}
+		}
+		exp >>= 1
+		if exp == 0 {
+			return result, true
+		}
+		if base, ok = checkedMul(base, base); !ok {
+			return 0, false
+		}
+	}
+}
+
+func checkedMul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return c, true
+}
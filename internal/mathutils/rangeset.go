@@ -0,0 +1,71 @@
+package mathutils
+
+import (
+	"slices"
+	"sort"
+)
+
+type RangeSet struct {
+	ranges []Range
+}
+
+func NewRangeSet(ranges ...*Range) *RangeSet {
+	s := &RangeSet{}
+	for _, r := range ranges {
+		s.Add(r)
+	}
+	return s
+}
+
+func (s *RangeSet) Add(r *Range) {
+	if r.Lo >= r.Hi {
+		return
+	}
+
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi >= r.Lo })
+	merged := *r
+	j := i
+	for j < len(s.ranges) && s.ranges[j].Lo <= merged.Hi {
+		merged.Lo = min(merged.Lo, s.ranges[j].Lo)
+		merged.Hi = max(merged.Hi, s.ranges[j].Hi)
+		j++
+	}
+	s.ranges = slices.Replace(s.ranges, i, j, merged)
+}
+
+func (s *RangeSet) Contains(v int) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > v })
+	return i < len(s.ranges) && s.ranges[i].Lo <= v
+}
+
+func (s *RangeSet) Size() int {
+	n := 0
+	for _, r := range s.ranges {
+		n += r.Size()
+	}
+	return n
+}
+
+func (s *RangeSet) Ranges() []*Range {
+	out := make([]*Range, len(s.ranges))
+	for i, r := range s.ranges {
+		out[i] = NewRange(r.Lo, r.Hi)
+	}
+	return out
+}
+
+func (s *RangeSet) Intersect(r *Range) []*Range {
+	var out []*Range
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > r.Lo })
+	for ; i < len(s.ranges) && s.ranges[i].Lo < r.Hi; i++ {
+		out = append(out, NewRange(max(s.ranges[i].Lo, r.Lo), min(s.ranges[i].Hi, r.Hi)))
+	}
+	return out
+}
+
+func (s *RangeSet) max() (int, bool) {
+	if len(s.ranges) == 0 {
+		return 0, false
+	}
+	return s.ranges[len(s.ranges)-1].Hi, true
+}
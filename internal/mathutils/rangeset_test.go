@@ -0,0 +1,77 @@
+package mathutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRangeSet_Add(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []*Range
+		expect []*Range
+	}{
+		{
+			name:   "disjoint ranges are sorted",
+			ranges: []*Range{{Lo: 10, Hi: 12}, {Lo: 0, Hi: 3}},
+			expect: []*Range{{Lo: 0, Hi: 3}, {Lo: 10, Hi: 12}},
+		},
+		{
+			name:   "overlapping ranges merge",
+			ranges: []*Range{{Lo: 0, Hi: 5}, {Lo: 3, Hi: 8}},
+			expect: []*Range{{Lo: 0, Hi: 8}},
+		},
+		{
+			name:   "adjacent ranges merge",
+			ranges: []*Range{{Lo: 0, Hi: 5}, {Lo: 5, Hi: 8}},
+			expect: []*Range{{Lo: 0, Hi: 8}},
+		},
+		{
+			name:   "bridging range merges several",
+			ranges: []*Range{{Lo: 0, Hi: 2}, {Lo: 4, Hi: 6}, {Lo: 8, Hi: 10}, {Lo: 1, Hi: 9}},
+			expect: []*Range{{Lo: 0, Hi: 10}},
+		},
+		{
+			name:   "empty ranges are ignored",
+			ranges: []*Range{{Lo: 3, Hi: 3}, {Lo: 5, Hi: 4}},
+			expect: []*Range{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewRangeSet(tt.ranges...).Ranges()
+			if !reflect.DeepEqual(result, tt.expect) {
+				t.Errorf("NewRangeSet(%v).Ranges() = %v, want %v", tt.ranges, result, tt.expect)
+			}
+		})
+	}
+}
+
+func TestRangeSet_Contains(t *testing.T) {
+	s := NewRangeSet(NewRange(0, 3), NewRange(10, 12))
+
+	for v, expect := range map[int]bool{-1: false, 0: true, 2: true, 3: false, 9: false, 10: true, 11: true, 12: false} {
+		if result := s.Contains(v); result != expect {
+			t.Errorf("Contains(%d) = %v, want %v", v, result, expect)
+		}
+	}
+
+	if result := s.Size(); result != 5 {
+		t.Errorf("Size() = %v, want %v", result, 5)
+	}
+}
+
+func TestRangeSet_Intersect(t *testing.T) {
+	s := NewRangeSet(NewRange(0, 3), NewRange(10, 12), NewRange(20, 30))
+
+	result := s.Intersect(NewRange(2, 25))
+	expect := []*Range{{Lo: 2, Hi: 3}, {Lo: 10, Hi: 12}, {Lo: 20, Hi: 25}}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Intersect() = %v, want %v", result, expect)
+	}
+
+	if result := s.Intersect(NewRange(4, 10)); result != nil {
+		t.Errorf("Intersect() = %v, want none", result)
+	}
+}
@@ -0,0 +1,193 @@
+package mathutils
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+type patternKind int
+
+const (
+	exactly patternKind = iota
+	atLeast
+	palindrome
+)
+
+type Pattern struct {
+	kind patternKind
+	k    int
+}
+
+func Exactly(k int) Pattern {
+	return Pattern{kind: exactly, k: k}
+}
+
+func AtLeast(k int) Pattern {
+	return Pattern{kind: atLeast, k: k}
+}
+
+func Palindrome() Pattern {
+	return Pattern{kind: palindrome}
+}
+
+func (p Pattern) allows(k int) bool {
+	if p.kind == exactly {
+		return k == p.k
+	}
+	return k >= p.k
+}
+
+func Repeated(base int, p Pattern, set *RangeSet) (iter.Seq[int], error) {
+	if base < 2 || base > 36 {
+		return nil, fmt.Errorf("base %d out of range [2, 36]", base)
+	}
+	if p.kind != palindrome && p.k < 1 {
+		return nil, fmt.Errorf("repetition count %d must be positive", p.k)
+	}
+
+	return func(yield func(int) bool) {
+		top, ok := set.max()
+		if !ok {
+			return
+		}
+
+		bandLo, bandHi := 0, base
+		for length := 1; bandLo < top; length++ {
+			pieces := set.Intersect(NewRange(bandLo, bandHi))
+			for _, r := range pieces {
+				var more bool
+				if p.kind == palindrome {
+					more = palindromes(base, length, r, yield)
+				} else {
+					more = repeats(base, length, p, r, yield)
+				}
+				if !more {
+					return
+				}
+			}
+
+			if bandHi == math.MaxInt {
+				return
+			}
+			bandLo = bandHi
+			if bandHi, ok = checkedMul(bandHi, base); !ok {
+				bandHi = math.MaxInt
+			}
+		}
+	}, nil
+}
+
+func repeats(base, length int, p Pattern, r *Range, yield func(int) bool) bool {
+	var blocks []int
+	for _, d := range append(ProperDivisors(length), length) {
+		if p.allows(length / d) {
+			blocks = append(blocks, d)
+		}
+	}
+
+	var found []int
+	for _, d := range blocks {
+		unit, ok := repunit(base, d, length/d)
+		if !ok {
+			continue
+		}
+
+		blockLo, blockHi := 0, math.MaxInt
+		if length > 1 {
+			blockLo, _ = Pow(base, d-1)
+		}
+		if hi, ok := Pow(base, d); ok {
+			blockHi = hi
+		}
+
+		bMin := max(blockLo, r.Lo/unit)
+		if bMin*unit < r.Lo {
+			bMin++
+		}
+		bMax := min(blockHi-1, (r.Hi-1)/unit)
+
+		for b := bMin; b <= bMax; b++ {
+			if len(blocks) == 1 {
+				if !yield(b * unit) {
+					return false
+				}
+				continue
+			}
+			found = append(found, b*unit)
+		}
+	}
+
+	slices.Sort(found)
+	for _, n := range slices.Compact(found) {
+		if !yield(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func repunit(base, d, k int) (int, bool) {
+	step, ok := Pow(base, d)
+	if !ok && k > 1 {
+		return 0, false
+	}
+
+	unit := 0
+	for range k {
+		if unit, ok = checkedMul(unit, step); !ok {
+			return 0, false
+		}
+		if unit == math.MaxInt {
+			return 0, false
+		}
+		unit++
+	}
+	return unit, true
+}
+
+func palindromes(base, length int, r *Range, yield func(int) bool) bool {
+	half := (length + 1) / 2
+	mirrored := length - half
+
+	halfLo, halfHi := 0, base
+	if length > 1 {
+		halfLo, _ = Pow(base, half-1)
+		halfHi, _ = Pow(base, half)
+	}
+	shift, _ := Pow(base, mirrored)
+	drop, _ := Pow(base, half-mirrored)
+
+	lo := max(halfLo, r.Lo/shift)
+	if n, ok := mirror(base, lo, shift, drop); !ok || n < r.Lo {
+		lo++
+	}
+
+	for h := lo; h < halfHi; h++ {
+		n, ok := mirror(base, h, shift, drop)
+		if !ok || n >= r.Hi {
+			break
+		}
+		if !yield(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func mirror(base, h, shift, drop int) (int, bool) {
+	n, ok := checkedMul(h, shift)
+	if !ok {
+		return 0, false
+	}
+
+	rev := 0
+	for h /= drop; h > 0; h /= base {
+		rev = rev*base + h%base
+	}
+	if n > math.MaxInt-rev {
+		return 0, false
+	}
+	return n + rev, true
+}
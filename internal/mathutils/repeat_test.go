@@ -0,0 +1,145 @@
+package mathutils
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRepeated(t *testing.T) {
+	set := NewRangeSet(NewRange(0, 700), NewRange(1500, 5000))
+
+	patterns := map[string]Pattern{
+		"exactly 1":  Exactly(1),
+		"exactly 2":  Exactly(2),
+		"exactly 3":  Exactly(3),
+		"at least 2": AtLeast(2),
+		"palindrome": Palindrome(),
+	}
+
+	for _, base := range []int{2, 3, 10, 16, 36} {
+		for name, p := range patterns {
+			t.Run(strconv.Itoa(base)+"/"+name, func(t *testing.T) {
+				seq, err := Repeated(base, p, set)
+				if err != nil {
+					t.Fatalf("Repeated() error = %v", err)
+				}
+
+				var expect []int
+				for n := range 5000 {
+					if set.Contains(n) && matches(strconv.FormatInt(int64(n), base), p) {
+						expect = append(expect, n)
+					}
+				}
+
+				result := slices.Collect(seq)
+				if !slices.Equal(result, expect) {
+					t.Errorf("Repeated(%d, %s) = %v, want %v", base, name, result, expect)
+				}
+			})
+		}
+	}
+}
+
+func matches(digits string, p Pattern) bool {
+	if p.kind == palindrome {
+		r := []byte(digits)
+		slices.Reverse(r)
+		return string(r) == digits
+	}
+	for k := 1; k <= len(digits); k++ {
+		if len(digits)%k == 0 && p.allows(k) && strings.Repeat(digits[:len(digits)/k], k) == digits {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRepeated_Example(t *testing.T) {
+	set := NewRangeSet(
+		NewRange(11, 23), NewRange(95, 116), NewRange(998, 1013),
+		NewRange(1188511880, 1188511891), NewRange(222220, 222225),
+		NewRange(1698522, 1698529), NewRange(446443, 446450),
+		NewRange(38593856, 38593863), NewRange(565653, 565660),
+		NewRange(824824821, 824824828), NewRange(2121212118, 2121212125),
+	)
+
+	tests := []struct {
+		pattern Pattern
+		expect  int
+	}{
+		{pattern: Exactly(2), expect: 1227775554},
+		{pattern: AtLeast(2), expect: 4174379265},
+	}
+
+	for _, tt := range tests {
+		seq, err := Repeated(10, tt.pattern, set)
+		if err != nil {
+			t.Fatalf("Repeated() error = %v", err)
+		}
+		sum := 0
+		for n := range seq {
+			sum += n
+		}
+		if sum != tt.expect {
+			t.Errorf("sum of Repeated(10, %v) = %v, want %v", tt.pattern, sum, tt.expect)
+		}
+	}
+}
+
+func TestRepeated_LargeValues(t *testing.T) {
+	set := NewRangeSet(NewRange(math.MaxInt-1_000_000_000_000, math.MaxInt))
+
+	for _, p := range []Pattern{AtLeast(2), Palindrome()} {
+		seq, err := Repeated(10, p, set)
+		if err != nil {
+			t.Fatalf("Repeated() error = %v", err)
+		}
+		for n := range seq {
+			if !set.Contains(n) || !matches(strconv.Itoa(n), p) {
+				t.Errorf("Repeated(10, %v) yielded %d", p, n)
+			}
+		}
+	}
+}
+
+func TestRepeated_Errors(t *testing.T) {
+	set := NewRangeSet(NewRange(0, 10))
+
+	if _, err := Repeated(1, Exactly(2), set); err == nil || err.Error() != "base 1 out of range [2, 36]" {
+		t.Errorf("Repeated(1) error = %v", err)
+	}
+	if _, err := Repeated(37, Exactly(2), set); err == nil || err.Error() != "base 37 out of range [2, 36]" {
+		t.Errorf("Repeated(37) error = %v", err)
+	}
+	if _, err := Repeated(10, AtLeast(0), set); err == nil || err.Error() != "repetition count 0 must be positive" {
+		t.Errorf("Repeated(AtLeast(0)) error = %v", err)
+	}
+}
+
+func TestPow(t *testing.T) {
+	tests := []struct {
+		base, exp, expect int
+		ok                bool
+	}{
+		{base: 10, exp: 0, expect: 1, ok: true},
+		{base: 10, exp: 18, expect: 1_000_000_000_000_000_000, ok: true},
+		{base: 10, exp: 19, ok: false},
+		{base: 2, exp: 62, expect: 1 << 62, ok: true},
+		{base: 2, exp: 63, ok: false},
+		{base: -2, exp: 63, expect: math.MinInt, ok: true},
+		{base: 36, exp: 3, expect: 46656, ok: true},
+		{base: -3, exp: 3, expect: -27, ok: true},
+		{base: 1, exp: 1 << 40, expect: 1, ok: true},
+		{base: 10, exp: -1, ok: false},
+	}
+
+	for _, tt := range tests {
+		result, ok := Pow(tt.base, tt.exp)
+		if ok != tt.ok || result != tt.expect {
+			t.Errorf("Pow(%d, %d) = %v, %v, want %v, %v", tt.base, tt.exp, result, ok, tt.expect, tt.ok)
+		}
+	}
+}
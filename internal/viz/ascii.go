@@ -0,0 +1,36 @@
+package viz
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+type ASCII struct {
+	w     *bufio.Writer
+	count int
+}
+
+func NewASCII(w io.Writer) *ASCII {
+	return &ASCII{w: bufio.NewWriter(w)}
+}
+
+func (a *ASCII) Render(f Frame) error {
+	if _, err := fmt.Fprintf(a.w, "Frame %d\n", a.count); err != nil {
+		return err
+	}
+	for _, row := range f {
+		if _, err := fmt.Fprintln(a.w, row); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(a.w); err != nil {
+		return err
+	}
+	a.count++
+	return nil
+}
+
+func (a *ASCII) Close() error {
+	return a.w.Flush()
+}
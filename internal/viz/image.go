@@ -0,0 +1,101 @@
+package viz
+
+import (
+	"fmt"
+	"image"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type GIF struct {
+	w       io.Writer
+	palette Palette
+	scale   int
+	Delay   int
+	frames  []Frame
+}
+
+func NewGIF(w io.Writer, palette Palette, scale int) *GIF {
+	return &GIF{
+		w:       w,
+		palette: palette,
+		scale:   scale,
+		Delay:   10,
+	}
+}
+
+func (g *GIF) Render(f Frame) error {
+	g.frames = append(g.frames, f)
+	return nil
+}
+
+func (g *GIF) Close() error {
+	rows, cols := 0, 0
+	for _, f := range g.frames {
+		r, c := f.size()
+		rows = max(rows, r)
+		cols = max(cols, c)
+	}
+
+	anim := &gif.GIF{}
+	colors := g.palette.colors()
+	bounds := image.Rect(0, 0, cols*g.scale, rows*g.scale)
+
+	for _, f := range g.frames {
+		img := image.NewPaletted(bounds, colors)
+		g.palette.draw(img, f, g.scale)
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, g.Delay)
+	}
+
+	if len(anim.Image) == 0 {
+		return fmt.Errorf("no frames to encode")
+	}
+
+	return gif.EncodeAll(g.w, anim)
+}
+
+type PNGSequence struct {
+	dir     string
+	palette Palette
+	scale   int
+	count   int
+}
+
+func NewPNGSequence(dir string, palette Palette, scale int) (*PNGSequence, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating directory: %w", err)
+	}
+	return &PNGSequence{
+		dir:     dir,
+		palette: palette,
+		scale:   scale,
+	}, nil
+}
+
+func (p *PNGSequence) Render(f Frame) error {
+	rows, cols := f.size()
+	img := image.NewPaletted(image.Rect(0, 0, cols*p.scale, rows*p.scale), p.palette.colors())
+	p.palette.draw(img, f, p.scale)
+
+	path := filepath.Join(p.dir, fmt.Sprintf("frame-%05d.png", p.count))
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+
+	if err := png.Encode(file, img); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("error encoding frame: %w", err)
+	}
+
+	p.count++
+	return file.Close()
+}
+
+func (p *PNGSequence) Close() error {
+	return nil
+}
@@ -0,0 +1,126 @@
+package viz
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"maps"
+	"os"
+	"slices"
+)
+
+type Frame []string
+
+func (f Frame) size() (int, int) {
+	cols := 0
+	for _, row := range f {
+		n := len([]rune(row))
+		if n > cols {
+			cols = n
+		}
+	}
+	return len(f), cols
+}
+
+type Renderer interface {
+	Render(f Frame) error
+	Close() error
+}
+
+type Palette map[rune]color.Color
+
+var DefaultPalette = Palette{
+	' ': color.Black,
+	'.': color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff},
+	'@': color.RGBA{R: 0xd0, G: 0xa0, B: 0x60, A: 0xff},
+	'x': color.RGBA{R: 0xc0, G: 0x30, B: 0x30, A: 0xff},
+	'S': color.RGBA{R: 0x30, G: 0xc0, B: 0x30, A: 0xff},
+	'^': color.RGBA{R: 0x60, G: 0x60, B: 0xc0, A: 0xff},
+	'|': color.RGBA{R: 0xf0, G: 0xe0, B: 0x40, A: 0xff},
+}
+
+var fallback = color.RGBA{R: 0x90, G: 0x90, B: 0x90, A: 0xff}
+
+func (p Palette) colors() color.Palette {
+	keys := slices.Sorted(maps.Keys(p))
+
+	pal := color.Palette{color.Black, fallback}
+	for _, k := range keys {
+		if !slices.ContainsFunc(pal, func(c color.Color) bool { return sameColor(c, p[k]) }) {
+			pal = append(pal, p[k])
+		}
+	}
+	return pal
+}
+
+func sameColor(a, b color.Color) bool {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
+
+func (p Palette) lookup(r rune) color.Color {
+	if c, ok := p[r]; ok {
+		return c
+	}
+	return fallback
+}
+
+func (p Palette) draw(img *image.Paletted, f Frame, scale int) {
+	for i, row := range f {
+		j := 0
+		for _, r := range row {
+			c := p.lookup(r)
+			for y := range scale {
+				for x := range scale {
+					img.Set(j*scale+x, i*scale+y, c)
+				}
+			}
+			j++
+		}
+	}
+}
+
+func New(kind, out string) (Renderer, error) {
+	switch kind {
+	case "ascii":
+		if out == "" {
+			return NewASCII(os.Stdout), nil
+		}
+		file, err := os.Create(out)
+		if err != nil {
+			return nil, fmt.Errorf("error creating file: %w", err)
+		}
+		return &closingRenderer{Renderer: NewASCII(file), closer: file}, nil
+	case "gif":
+		if out == "" {
+			out = "out.gif"
+		}
+		file, err := os.Create(out)
+		if err != nil {
+			return nil, fmt.Errorf("error creating file: %w", err)
+		}
+		return &closingRenderer{Renderer: NewGIF(file, DefaultPalette, 4), closer: file}, nil
+	case "png":
+		if out == "" {
+			out = "frames"
+		}
+		return NewPNGSequence(out, DefaultPalette, 4)
+	default:
+		return nil, fmt.Errorf("unknown visualisation: %q", kind)
+	}
+}
+
+type closingRenderer struct {
+	Renderer
+	closer io.Closer
+}
+
+func (c *closingRenderer) Close() error {
+	if err := c.Renderer.Close(); err != nil {
+		_ = c.closer.Close()
+		return err
+	}
+	return c.closer.Close()
+}
@@ -0,0 +1,124 @@
+package viz
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestASCII_Render(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewASCII(&buf)
+
+	frames := []Frame{
+		{"@@.", ".@."},
+		{"x@.", ".x."},
+	}
+	for _, f := range frames {
+		if err := r.Render(f); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	expected := "Frame 0\n@@.\n.@.\n\nFrame 1\nx@.\n.x.\n\n"
+	if buf.String() != expected {
+		t.Errorf("Render() = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestGIF_Render(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewGIF(&buf, DefaultPalette, 2)
+
+	frames := []Frame{
+		{"S.."},
+		{"S..", "^|."},
+		{"S..", "^|.", "..|"},
+	}
+	for _, f := range frames {
+		if err := r.Render(f); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("DecodeAll() error = %v", err)
+	}
+	if len(anim.Image) != len(frames) {
+		t.Fatalf("frames = %v, want %v", len(anim.Image), len(frames))
+	}
+
+	bounds := anim.Image[0].Bounds()
+	if bounds.Dx() != 6 || bounds.Dy() != 6 {
+		t.Errorf("bounds = %v, want 6x6", bounds)
+	}
+
+	if !sameColor(anim.Image[2].At(5, 5), DefaultPalette['|']) {
+		t.Errorf("At(5, 5) = %v, want %v", anim.Image[2].At(5, 5), DefaultPalette['|'])
+	}
+	if !sameColor(anim.Image[0].At(5, 5), color.Black) {
+		t.Errorf("At(5, 5) = %v, want %v", anim.Image[0].At(5, 5), color.Black)
+	}
+}
+
+func TestGIF_Close_NoFrames(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewGIF(&buf, DefaultPalette, 1)
+
+	if err := r.Close(); err == nil {
+		t.Errorf("Close() error = %v, want error", err)
+	}
+}
+
+func TestPNGSequence_Render(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "frames")
+	r, err := NewPNGSequence(dir, DefaultPalette, 3)
+	if err != nil {
+		t.Fatalf("NewPNGSequence() error = %v", err)
+	}
+
+	for _, f := range []Frame{{"@."}, {"x."}} {
+		if err := r.Render(f); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "frame-00001.png"))
+	if err != nil {
+		t.Fatalf("error opening frame: %v", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if img.Bounds().Dx() != 6 || img.Bounds().Dy() != 3 {
+		t.Errorf("bounds = %v, want 6x3", img.Bounds())
+	}
+	if !sameColor(img.At(0, 0), DefaultPalette['x']) {
+		t.Errorf("At(0, 0) = %v, want %v", img.At(0, 0), DefaultPalette['x'])
+	}
+}
+
+func TestNew_Unknown(t *testing.T) {
+	if _, err := New("mp4", ""); err == nil {
+		t.Errorf("New() error = %v, want error", err)
+	}
+}